@@ -0,0 +1,160 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/moby/patternmatcher"
+	"github.com/moby/patternmatcher/ignorefile"
+)
+
+// defaultContextFileMode is the mode of the in-memory files of the build context without mode
+const defaultContextFileMode = 0o644
+
+// ContextFile is a file of the build context created in memory, e.g. a Dockerfile generated by a test
+type ContextFile struct {
+	Content []byte
+	Mode    int64 // the permissions of the file, defaults to 0o644
+}
+
+// contextEntry is a file or directory of the build context, from the ContextFS or the ContextFiles
+type contextEntry struct {
+	dir     bool
+	mode    int64
+	content []byte
+}
+
+// hasContextFS returns true if the build context is a file system or a set of in-memory files
+func (c *ContainerRequest) hasContextFS() bool {
+	return c.FromDockerfile.ContextFS != nil || len(c.FromDockerfile.ContextFiles) > 0
+}
+
+// readContextFile returns the content of a file of the build context, from the in-memory files first,
+// and then from the file system
+func (c *ContainerRequest) readContextFile(name string) ([]byte, error) {
+	if f, ok := c.FromDockerfile.ContextFiles[name]; ok {
+		return f.Content, nil
+	}
+
+	if c.FromDockerfile.ContextFS == nil {
+		return nil, fmt.Errorf("open %s: %w", name, fs.ErrNotExist)
+	}
+	return fs.ReadFile(c.FromDockerfile.ContextFS, name)
+}
+
+// contextFSArchive returns the tar archive of the build context, with the files of the ContextFS and the
+// ContextFiles, which replace the ones with the same path, except the ones ignored by its .dockerignore file.
+// The entries are sorted, without modification times, so the same files always give the same archive.
+func (c *ContainerRequest) contextFSArchive() ([]byte, error) {
+	entries, err := c.contextEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	var excluded []string
+	if ignore, err := c.readContextFile(".dockerignore"); err == nil {
+		excluded, err = ignorefile.ReadAll(bytes.NewReader(ignore))
+		if err != nil {
+			return nil, fmt.Errorf("error reading .dockerignore: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	pm, err := patternmatcher.New(excluded)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing .dockerignore: %w", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range names {
+		skip, err := pm.MatchesOrParentMatches(filepath.FromSlash(name))
+		if err != nil {
+			return nil, err
+		}
+		if skip {
+			continue
+		}
+
+		entry := entries[name]
+		header := &tar.Header{Name: name, Mode: entry.mode, Typeflag: tar.TypeReg, Size: int64(len(entry.content))}
+		if entry.dir {
+			header = &tar.Header{Name: name + "/", Mode: entry.mode, Typeflag: tar.TypeDir}
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(entry.content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// contextEntries returns the files and directories of the ContextFS, replaced by the ContextFiles, by path
+func (c *ContainerRequest) contextEntries() (map[string]contextEntry, error) {
+	entries := map[string]contextEntry{}
+
+	if c.FromDockerfile.ContextFS != nil {
+		err := fs.WalkDir(c.FromDockerfile.ContextFS, ".", func(name string, d fs.DirEntry, err error) error {
+			if err != nil || name == "." {
+				return err
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case d.IsDir():
+				entries[name] = contextEntry{dir: true, mode: int64(info.Mode().Perm())}
+			case info.Mode().IsRegular():
+				content, err := fs.ReadFile(c.FromDockerfile.ContextFS, name)
+				if err != nil {
+					return err
+				}
+				entries[name] = contextEntry{mode: int64(info.Mode().Perm()), content: content}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("read build context: %w", err)
+		}
+	}
+
+	for name, f := range c.FromDockerfile.ContextFiles {
+		cleaned := path.Clean(name)
+		if !fs.ValidPath(cleaned) || cleaned == "." {
+			return nil, fmt.Errorf("invalid path %q of the build context: it must be relative, and slash-separated", name)
+		}
+
+		mode := f.Mode
+		if mode == 0 {
+			mode = defaultContextFileMode
+		}
+		entries[cleaned] = contextEntry{mode: mode, content: f.Content}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,85 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readArchive returns the modes and contents of the entries of a tar archive, by name
+func readArchive(t *testing.T, archive []byte) map[string]ContextFile {
+	t.Helper()
+
+	entries := map[string]ContextFile{}
+	tr := tar.NewReader(bytes.NewReader(archive))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return entries
+		}
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		entries[header.Name] = ContextFile{Content: content, Mode: header.Mode}
+	}
+}
+
+func TestContextFSArchive(t *testing.T) {
+	req := ContainerRequest{
+		FromDockerfile: FromDockerfile{
+			ContextFS: fstest.MapFS{
+				"Dockerfile":    {Data: []byte("FROM alpine"), Mode: 0o644},
+				"app/run.sh":    {Data: []byte("echo run"), Mode: 0o755},
+				"app/debug.log": {Data: []byte("debug"), Mode: 0o644},
+				".dockerignore": {Data: []byte("**/*.log\n"), Mode: 0o644},
+			},
+			ContextFiles: map[string]ContextFile{
+				"Dockerfile":     {Content: []byte("FROM alpine:3.18")},
+				"config/app.yml": {Content: []byte("port: 8080"), Mode: 0o600},
+			},
+		},
+	}
+
+	archive, err := req.contextFSArchive()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]ContextFile{
+		".dockerignore":  {Content: []byte("**/*.log\n"), Mode: 0o644},
+		"Dockerfile":     {Content: []byte("FROM alpine:3.18"), Mode: defaultContextFileMode},
+		"app/":           {Content: []byte{}, Mode: 0o555},
+		"app/run.sh":     {Content: []byte("echo run"), Mode: 0o755},
+		"config/app.yml": {Content: []byte("port: 8080"), Mode: 0o600},
+	}, readArchive(t, archive))
+
+	t.Run("same files give the same archive", func(t *testing.T) {
+		again, err := req.contextFSArchive()
+		require.NoError(t, err)
+		assert.Equal(t, archive, again)
+	})
+
+	t.Run("dockerfile is read from the in-memory files first", func(t *testing.T) {
+		dockerfile, err := req.readContextFile("Dockerfile")
+		require.NoError(t, err)
+		assert.Equal(t, "FROM alpine:3.18", string(dockerfile))
+	})
+}
+
+func TestContextFSArchive_invalidPath(t *testing.T) {
+	req := ContainerRequest{
+		FromDockerfile: FromDockerfile{
+			ContextFiles: map[string]ContextFile{
+				"../Dockerfile": {Content: []byte("FROM alpine")},
+			},
+		},
+	}
+
+	_, err := req.contextFSArchive()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid path "../Dockerfile"`)
+}
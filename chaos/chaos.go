@@ -0,0 +1,369 @@
+// Package chaos injects network faults, such as latency, packet loss, bandwidth limits or partitions,
+// into running containers. The faults are applied with tc netem, from a helper container sharing
+// the network namespace of the target container, so the image of the target does not need any tool.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+const (
+	// defaultImage provides tc and ip in a multi-arch image
+	defaultImage = "nicolaka/netshoot:v0.11"
+
+	// bands is the number of bands of the prio qdisc installed on each interface.
+	// The first band carries the traffic without faults, so 15 rules can be applied per interface.
+	bands = 16
+)
+
+// Injector applies network faults to the traffic sent by a container
+type Injector struct {
+	target testcontainers.Container
+	helper testcontainers.Container
+
+	mtx sync.Mutex
+	// used holds the bands used by rules, for each interface with the prio qdisc
+	used  map[string][bands]bool
+	rules map[*Rule]struct{}
+}
+
+// Rule is a fault applied to the traffic sent by the container to some destinations
+type Rule struct {
+	injector *Injector
+	fault    Fault
+	// bands are the bands of the rule, on each interface carrying traffic to the destinations
+	bands map[string]int
+}
+
+type options struct {
+	image string
+}
+
+// Option configures the injector
+type Option func(*options)
+
+// WithImage sets the image of the helper container, which must provide the tc and ip commands.
+// By default, the nicolaka/netshoot image is used.
+func WithImage(image string) Option {
+	return func(o *options) {
+		o.image = image
+	}
+}
+
+// New starts a helper container sharing the network namespace of the target container,
+// which is used to apply faults to the traffic sent by the target. The target must be running,
+// and the injector must be terminated after use.
+func New(ctx context.Context, target testcontainers.Container, opts ...Option) (*Injector, error) {
+	o := options{image: defaultImage}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	helper, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      o.image,
+			Entrypoint: []string{"tail", "-f", "/dev/null"},
+			HostConfigModifier: func(hc *container.HostConfig) {
+				hc.NetworkMode = container.NetworkMode("container:" + target.GetContainerID())
+				hc.CapAdd = append(hc.CapAdd, "NET_ADMIN")
+			},
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("start chaos helper: %w", err)
+	}
+
+	return &Injector{
+		target: target,
+		helper: helper,
+		used:   make(map[string][bands]bool),
+		rules:  make(map[*Rule]struct{}),
+	}, nil
+}
+
+// Apply applies the fault to the traffic sent by the container to the given destinations,
+// or to all its traffic if no destination is given. The returned rule removes the fault.
+//
+// As faults only apply to the traffic sent by the container, a fault between two containers
+// applies to one direction only. Partitions are complete though, as the responses are dropped.
+func (i *Injector) Apply(ctx context.Context, fault Fault, destinations ...Destination) (*Rule, error) {
+	netemArgs, err := fault.netemArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	// addresses by interface, nil for all the traffic of the interface
+	routes := make(map[string][]string)
+	if len(destinations) == 0 {
+		devices, err := i.devices(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, dev := range devices {
+			routes[dev] = nil
+		}
+	}
+
+	for _, dest := range destinations {
+		addresses, err := dest(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, addr := range addresses {
+			dev, err := i.route(ctx, addr)
+			if err != nil {
+				return nil, err
+			}
+			routes[dev] = append(routes[dev], addr)
+		}
+	}
+
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+
+	rule := &Rule{injector: i, fault: fault, bands: make(map[string]int)}
+	for dev, addresses := range routes {
+		band, err := i.addBand(ctx, dev, netemArgs, addresses)
+		if err != nil {
+			// do not leave a partial rule behind
+			return nil, errors.Join(err, rule.remove(ctx))
+		}
+		rule.bands[dev] = band
+	}
+
+	i.rules[rule] = struct{}{}
+	return rule, nil
+}
+
+// Fault returns the fault applied by the rule
+func (r *Rule) Fault() Fault {
+	return r.fault
+}
+
+// Remove removes the fault of the rule, restoring the traffic to the destinations
+func (r *Rule) Remove(ctx context.Context) error {
+	r.injector.mtx.Lock()
+	defer r.injector.mtx.Unlock()
+
+	if _, ok := r.injector.rules[r]; !ok {
+		return nil
+	}
+	delete(r.injector.rules, r)
+
+	return r.remove(ctx)
+}
+
+// remove deletes the bands of the rule, with the lock of the injector held
+func (r *Rule) remove(ctx context.Context) error {
+	var errs []error
+	for dev, band := range r.bands {
+		if err := r.injector.removeBand(ctx, dev, band); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	r.bands = map[string]int{}
+	return errors.Join(errs...)
+}
+
+// Clear removes all the faults applied to the container
+func (i *Injector) Clear(ctx context.Context) error {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+
+	var errs []error
+	for dev := range i.used {
+		if err := i.tc(ctx, "qdisc", "del", "dev", dev, "root"); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	i.used = make(map[string][bands]bool)
+	i.rules = make(map[*Rule]struct{})
+	return errors.Join(errs...)
+}
+
+// Terminate removes all the faults and terminates the helper container
+func (i *Injector) Terminate(ctx context.Context) error {
+	// the faults cannot be removed if the target is already terminated
+	var errs []error
+	if i.target.IsRunning() {
+		errs = append(errs, i.Clear(ctx))
+	}
+	errs = append(errs, i.helper.Terminate(ctx))
+	return errors.Join(errs...)
+}
+
+// addBand adds a band with the netem qdisc to the interface, and routes the traffic to the addresses to it
+func (i *Injector) addBand(ctx context.Context, dev string, netemArgs []string, addresses []string) (int, error) {
+	used, ok := i.used[dev]
+	if !ok {
+		priomap := make([]string, 16)
+		for j := range priomap {
+			priomap[j] = "0"
+		}
+		args := append([]string{"qdisc", "add", "dev", dev, "root", "handle", "1:", "prio", "bands", strconv.Itoa(bands), "priomap"}, priomap...)
+		if err := i.tc(ctx, args...); err != nil {
+			return 0, err
+		}
+		i.used[dev] = used
+	}
+
+	band := 0
+	for b := 1; b < bands; b++ {
+		if !used[b] {
+			band = b
+			break
+		}
+	}
+	if band == 0 {
+		return 0, fmt.Errorf("too many faults on interface %s, at most %d are supported", dev, bands-1)
+	}
+
+	args := append([]string{"qdisc", "add", "dev", dev, "parent", bandClass(band), "handle", bandHandle(band), "netem"}, netemArgs...)
+	if err := i.tc(ctx, args...); err != nil {
+		return 0, err
+	}
+
+	used[band] = true
+	i.used[dev] = used
+
+	if len(addresses) == 0 {
+		addresses = []string{"0.0.0.0/0"}
+	}
+	for _, addr := range addresses {
+		if !strings.Contains(addr, "/") {
+			addr += "/32"
+		}
+		if err := i.tc(ctx, "filter", "add", "dev", dev, "parent", "1:", "protocol", "ip", "prio", strconv.Itoa(band),
+			"u32", "match", "ip", "dst", addr, "flowid", bandClass(band)); err != nil {
+			return band, errors.Join(err, i.removeBand(ctx, dev, band))
+		}
+	}
+
+	return band, nil
+}
+
+// removeBand removes the filters and the netem qdisc of the band
+func (i *Injector) removeBand(ctx context.Context, dev string, band int) error {
+	used := i.used[dev]
+	if !used[band] {
+		return nil
+	}
+
+	// the filters may not exist if the rule failed to be applied
+	_ = i.tc(ctx, "filter", "del", "dev", dev, "parent", "1:", "protocol", "ip", "prio", strconv.Itoa(band))
+	if err := i.tc(ctx, "qdisc", "del", "dev", dev, "parent", bandClass(band), "handle", bandHandle(band)); err != nil {
+		return err
+	}
+
+	used[band] = false
+	i.used[dev] = used
+	return nil
+}
+
+// bandClass returns the class of the prio qdisc for the band, whose minor number is written in hexadecimal
+func bandClass(band int) string {
+	return fmt.Sprintf("1:%x", band+1)
+}
+
+// bandHandle returns the handle of the netem qdisc of the band
+func bandHandle(band int) string {
+	return fmt.Sprintf("%x:", 0x10+band)
+}
+
+// devices returns the network interfaces of the container, except the loopback
+func (i *Injector) devices(ctx context.Context) ([]string, error) {
+	out, err := i.exec(ctx, "ls", "/sys/class/net")
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []string
+	for _, dev := range strings.Fields(out) {
+		if dev != "lo" {
+			devices = append(devices, dev)
+		}
+	}
+	return devices, nil
+}
+
+// route returns the network interface used to send traffic to the address
+func (i *Injector) route(ctx context.Context, addr string) (string, error) {
+	out, err := i.exec(ctx, "ip", "-4", "route", "get", strings.Split(addr, "/")[0])
+	if err != nil {
+		return "", err
+	}
+
+	dev, ok := parseRouteField(out, "dev")
+	if !ok {
+		return "", fmt.Errorf("no route to %s: %s", addr, out)
+	}
+	return dev, nil
+}
+
+// gateway returns the addresses of the default gateways of the container, which are the host
+func (i *Injector) gateway(ctx context.Context) ([]string, error) {
+	out, err := i.exec(ctx, "ip", "-4", "route", "show", "default")
+	if err != nil {
+		return nil, err
+	}
+
+	var gateways []string
+	for _, line := range strings.Split(out, "\n") {
+		if gw, ok := parseRouteField(line, "via"); ok {
+			gateways = append(gateways, gw)
+		}
+	}
+	if len(gateways) == 0 {
+		return nil, errors.New("the container has no default gateway")
+	}
+	return gateways, nil
+}
+
+// parseRouteField returns the value following the keyword in the output of ip route
+func parseRouteField(out string, keyword string) (string, bool) {
+	fields := strings.Fields(out)
+	for j := 0; j+1 < len(fields); j++ {
+		if fields[j] == keyword {
+			return fields[j+1], true
+		}
+	}
+	return "", false
+}
+
+func (i *Injector) tc(ctx context.Context, args ...string) error {
+	_, err := i.exec(ctx, append([]string{"tc"}, args...)...)
+	return err
+}
+
+// exec runs the command in the helper container, returning its output
+func (i *Injector) exec(ctx context.Context, cmd ...string) (string, error) {
+	code, reader, err := i.helper.Exec(ctx, cmd, tcexec.Multiplexed())
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.Join(cmd, " "), err)
+	}
+
+	out, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.Join(cmd, " "), err)
+	}
+
+	if code != 0 {
+		return "", fmt.Errorf("%s: exit code %d: %s", strings.Join(cmd, " "), code, strings.TrimSpace(string(out)))
+	}
+
+	return string(out), nil
+}
@@ -0,0 +1,168 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestFaultNetemArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fault    Fault
+		expected []string
+		err      string
+	}{
+		{
+			name:     "latency",
+			fault:    Latency(100*time.Millisecond, 0),
+			expected: []string{"delay", "100000us"},
+		},
+		{
+			name:     "latency with jitter",
+			fault:    Latency(100*time.Millisecond, 1500*time.Microsecond),
+			expected: []string{"delay", "100000us", "1500us"},
+		},
+		{
+			name:     "loss",
+			fault:    Loss(12.5),
+			expected: []string{"loss", "12.5%"},
+		},
+		{
+			name:     "bandwidth",
+			fault:    Bandwidth(1_000_000),
+			expected: []string{"rate", "1000000bit"},
+		},
+		{
+			name:     "partition",
+			fault:    Partition(),
+			expected: []string{"loss", "100%"},
+		},
+		{
+			name:     "combined",
+			fault:    Fault{Latency: time.Second, Loss: 1, Bandwidth: 8000},
+			expected: []string{"delay", "1000000us", "loss", "1%", "rate", "8000bit"},
+		},
+		{
+			name:  "no effect",
+			fault: Fault{},
+			err:   "the fault has no effect",
+		},
+		{
+			name:  "jitter without latency",
+			fault: Fault{Jitter: time.Millisecond},
+			err:   "jitter requires a latency",
+		},
+		{
+			name:  "invalid loss",
+			fault: Loss(101),
+			err:   "loss must be a percentage between 0 and 100, got 101",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := tt.fault.netemArgs()
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, args)
+		})
+	}
+}
+
+func TestParseRouteField(t *testing.T) {
+	out := "172.18.0.3 dev eth1 src 172.18.0.2 uid 0 \n    cache "
+
+	dev, ok := parseRouteField(out, "dev")
+	require.True(t, ok)
+	assert.Equal(t, "eth1", dev)
+
+	_, ok = parseRouteField(out, "via")
+	assert.False(t, ok)
+}
+
+func TestBandClassAndHandle(t *testing.T) {
+	assert.Equal(t, "1:2", bandClass(1))
+	assert.Equal(t, "1:10", bandClass(15))
+	assert.Equal(t, "11:", bandHandle(1))
+	assert.Equal(t, "1f:", bandHandle(15))
+}
+
+func TestInjector(t *testing.T) {
+	ctx := context.Background()
+
+	nginx, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "nginx:alpine",
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForHTTP("/").WithPort("80/tcp"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nginx.Terminate(ctx))
+	})
+
+	endpoint, err := nginx.PortEndpoint(ctx, "80/tcp", "http")
+	require.NoError(t, err)
+
+	injector, err := New(ctx, nginx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, injector.Terminate(ctx))
+	})
+
+	get := func(timeout time.Duration) (time.Duration, error) {
+		client := http.Client{Timeout: timeout}
+		start := time.Now()
+		resp, err := client.Get(endpoint)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return 0, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+		return time.Since(start), nil
+	}
+
+	t.Run("latency", func(t *testing.T) {
+		rule, err := injector.Apply(ctx, Latency(500*time.Millisecond, 0), ToHost())
+		require.NoError(t, err)
+
+		elapsed, err := get(10 * time.Second)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, elapsed, 500*time.Millisecond)
+
+		require.NoError(t, rule.Remove(ctx))
+
+		elapsed, err = get(10 * time.Second)
+		require.NoError(t, err)
+		assert.Less(t, elapsed, 500*time.Millisecond)
+	})
+
+	t.Run("partition", func(t *testing.T) {
+		_, err := injector.Apply(ctx, Partition())
+		require.NoError(t, err)
+
+		_, err = get(2 * time.Second)
+		require.Error(t, err)
+
+		require.NoError(t, injector.Clear(ctx))
+
+		_, err = get(10 * time.Second)
+		require.NoError(t, err)
+	})
+}
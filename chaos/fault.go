@@ -0,0 +1,127 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Fault describes the network conditions of the traffic sent by a container.
+// The conditions can be combined, e.g. latency and packet loss.
+type Fault struct {
+	// Latency delays the packets
+	Latency time.Duration
+	// Jitter varies the latency randomly, up to the given duration. It requires a latency.
+	Jitter time.Duration
+	// Loss is the percentage of dropped packets, between 0 and 100
+	Loss float64
+	// Bandwidth limits the rate of the traffic, in bits per second
+	Bandwidth uint64
+	// Partition drops all the packets
+	Partition bool
+}
+
+// Latency returns a fault delaying the packets by the latency, varying randomly up to the jitter
+func Latency(latency time.Duration, jitter time.Duration) Fault {
+	return Fault{Latency: latency, Jitter: jitter}
+}
+
+// Loss returns a fault dropping the given percentage of packets
+func Loss(percent float64) Fault {
+	return Fault{Loss: percent}
+}
+
+// Bandwidth returns a fault limiting the rate of the traffic, in bits per second
+func Bandwidth(bitsPerSecond uint64) Fault {
+	return Fault{Bandwidth: bitsPerSecond}
+}
+
+// Partition returns a fault dropping all the packets
+func Partition() Fault {
+	return Fault{Partition: true}
+}
+
+// netemArgs validates the fault and returns the arguments of the netem qdisc applying it
+func (f Fault) netemArgs() ([]string, error) {
+	if f.Latency < 0 || f.Jitter < 0 {
+		return nil, errors.New("latency and jitter must not be negative")
+	}
+	if f.Jitter > 0 && f.Latency == 0 {
+		return nil, errors.New("jitter requires a latency")
+	}
+	if f.Loss < 0 || f.Loss > 100 {
+		return nil, fmt.Errorf("loss must be a percentage between 0 and 100, got %g", f.Loss)
+	}
+
+	var args []string
+	if f.Latency > 0 {
+		args = append(args, "delay", formatMicroseconds(f.Latency))
+		if f.Jitter > 0 {
+			args = append(args, formatMicroseconds(f.Jitter))
+		}
+	}
+
+	switch {
+	case f.Partition:
+		args = append(args, "loss", "100%")
+	case f.Loss > 0:
+		args = append(args, "loss", strconv.FormatFloat(f.Loss, 'f', -1, 64)+"%")
+	}
+
+	if f.Bandwidth > 0 {
+		args = append(args, "rate", strconv.FormatUint(f.Bandwidth, 10)+"bit")
+	}
+
+	if len(args) == 0 {
+		return nil, errors.New("the fault has no effect")
+	}
+
+	return args, nil
+}
+
+func formatMicroseconds(d time.Duration) string {
+	return strconv.FormatInt(d.Microseconds(), 10) + "us"
+}
+
+// Destination selects the addresses of the traffic affected by a fault
+type Destination func(ctx context.Context, i *Injector) ([]string, error)
+
+// ToHost selects the traffic sent to the host, which is the default gateway of the container.
+// It includes the responses to the connections opened from the host through the mapped ports.
+func ToHost() Destination {
+	return func(ctx context.Context, i *Injector) ([]string, error) {
+		return i.gateway(ctx)
+	}
+}
+
+// ToContainer selects the traffic sent to another container, on all its networks
+func ToContainer(c testcontainers.Container) Destination {
+	return func(ctx context.Context, _ *Injector) ([]string, error) {
+		ips, err := c.ContainerIPs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(ips) == 0 {
+			return nil, fmt.Errorf("container %s has no IP address", c.GetContainerID())
+		}
+		return ips, nil
+	}
+}
+
+// ToAddress selects the traffic sent to an IPv4 address or network, such as "10.0.0.1" or "10.0.0.0/24"
+func ToAddress(addr string) Destination {
+	return func(_ context.Context, _ *Injector) ([]string, error) {
+		if _, _, err := net.ParseCIDR(addr); err == nil {
+			return []string{addr}, nil
+		}
+		if ip := net.ParseIP(addr); ip == nil || ip.To4() == nil {
+			return nil, fmt.Errorf("invalid IPv4 address %q", addr)
+		}
+		return []string{addr}, nil
+	}
+}
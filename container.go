@@ -1,10 +1,14 @@
 package testcontainers
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -76,11 +80,28 @@ type ImageBuildInfo interface {
 	GetAuthConfigs() map[string]registry.AuthConfig // Deprecated. Testcontainers will detect registry credentials automatically. Return the auth configs to be able to pull from an authenticated docker registry
 }
 
+// buildKitImageBuildInfo defines what is needed to build an image with BuildKit,
+// and to report the progress of the build. It's implemented by ContainerRequest.
+type buildKitImageBuildInfo interface {
+	GetBuildSecrets() []BuildSecret                  // the secrets exposed to the build
+	GetBuildSSH() []BuildSSH                         // the SSH agents or keys forwarded to the build
+	GetBuildProgressConsumer() BuildProgressConsumer // the consumer of the progress of the build
+}
+
+// reusableImageBuildInfo defines whether a built image can be reused instead of built again,
+// as its tag is the hash of the build. It's implemented by ContainerRequest.
+type reusableImageBuildInfo interface {
+	ShouldReuseBuiltImage() bool       // return true if the image is tagged with the hash of the build
+	reusableImageTag() (string, error) // return the tag of the image, named after the hash of the build
+}
+
 // FromDockerfile represents the parameters needed to build an image from a Dockerfile
 // rather than using a pre-built one
 type FromDockerfile struct {
 	Context        string                         // the path to the context of the docker build
 	ContextArchive io.Reader                      // the tar archive file to send to docker that contains the build context
+	ContextFS      fs.FS                          // the file system with the build context, e.g. an embed.FS, sent as a tar archive
+	ContextFiles   map[string]ContextFile         // the in-memory files of the build context by slash-separated path, replacing the ContextFS ones
 	Dockerfile     string                         // the path from the context to the Dockerfile for the image, defaults to "Dockerfile"
 	Repo           string                         // the repo label for image, defaults to UUID
 	Tag            string                         // the tag label for image, defaults to UUID
@@ -95,6 +116,25 @@ type FromDockerfile struct {
 	// advanced configurations while building the image. Please consider that the modifier
 	// is called after the default build options are set.
 	BuildOptionsModifier func(*types.ImageBuildOptions)
+	// Target is the stage of a multi-stage Dockerfile to build, the last one if empty
+	Target string
+	// Secrets are exposed to the RUN --mount=type=secret instructions, without being stored in the image.
+	// They need BuildKit, which is enabled when they are set.
+	Secrets []BuildSecret
+	// SSH forwards SSH agents or keys to the RUN --mount=type=ssh instructions.
+	// It needs BuildKit, which is enabled when it is set.
+	SSH []BuildSSH
+	// CacheFrom are the images to use as cache sources. With BuildKit, they must have been built
+	// with the BUILDKIT_INLINE_CACHE=1 build arg.
+	CacheFrom []string
+	// Platform is the platform to build the image for, e.g. "linux/arm64", instead of the platform of the daemon
+	Platform string
+	// Labels are added to the built image
+	Labels map[string]string
+	// BuildKit enables building the image with BuildKit, instead of the legacy builder
+	BuildKit bool
+	// BuildProgressConsumer receives the progress of the steps of the build, and their output
+	BuildProgressConsumer BuildProgressConsumer
 }
 
 type ContainerFile struct {
@@ -121,6 +161,7 @@ type ContainerRequest struct {
 	Hostname                string
 	WorkingDir              string                                     // specify the working directory of the container
 	ExtraHosts              []string                                   // Deprecated: Use HostConfigModifier instead
+	HostAccessPorts         []int                                      // ports of the host the container can reach on host.testcontainers.internal
 	Privileged              bool                                       // For starting privileged container
 	Networks                []string                                   // for specifying network names
 	NetworkAliases          map[string][]string                        // for specifying network aliases
@@ -132,12 +173,15 @@ type ContainerRequest struct {
 	ReaperImage             string                                     // Deprecated: use WithImageName ContainerOption instead. Alternative reaper image
 	ReaperOptions           []ContainerOption                          // Deprecated: the reaper is configured at the properties level, for an entire test session
 	AutoRemove              bool                                       // Deprecated: Use HostConfigModifier instead. If set to true, the container will be removed from the host when stopped
-	AlwaysPullImage         bool                                       // Always pull image
+	AlwaysPullImage         bool                                       // Always pull image, overriding the PullPolicy
+	PullPolicy              PullPolicy                                 // decides whether to pull the image, PullIfMissing by default
+	PullProgressConsumer    PullProgressConsumer                       // receives the progress of the pull of the image, which is logged by default
 	ImagePlatform           string                                     // ImagePlatform describes the platform which the image runs on.
 	Binds                   []string                                   // Deprecated: Use HostConfigModifier instead
 	ShmSize                 int64                                      // Amount of memory shared with the host (in bytes)
 	CapAdd                  []string                                   // Deprecated: Use HostConfigModifier instead. Add Linux capabilities
 	CapDrop                 []string                                   // Deprecated: Use HostConfigModifier instead. Drop Linux capabilities
+	HealthCheck             *container.HealthConfig                    // Health check of the container, which can be waited for with wait.ForHealthCheck
 	ConfigModifier          func(*container.Config)                    // Modifier for the config before container creation
 	HostConfigModifier      func(*container.HostConfig)                // Modifier for the host config before container creation
 	EnpointSettingsModifier func(map[string]*network.EndpointSettings) // Modifier for the network settings before container creation
@@ -196,6 +240,14 @@ func (c *ContainerRequest) GetContext() (io.Reader, error) {
 		return c.ContextArchive, nil
 	}
 
+	if c.hasContextFS() {
+		archive, err := c.contextFSArchive()
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(archive), nil
+	}
+
 	// always pass context as absolute path
 	abs, err := filepath.Abs(c.Context)
 	if err != nil {
@@ -244,20 +296,33 @@ func (c *ContainerRequest) GetDockerfile() string {
 	return f
 }
 
-// GetRepo returns the Repo label for image from the ContainerRequest, defaults to UUID
+// GetRepo returns the Repo label for image from the ContainerRequest, defaults to UUID,
+// or to a fixed name if the built image is reused, see ShouldReuseBuiltImage
 func (c *ContainerRequest) GetRepo() string {
 	r := c.FromDockerfile.Repo
 	if r == "" {
+		if c.ShouldReuseBuiltImage() {
+			return reusableImageRepo
+		}
 		return uuid.NewString()
 	}
 
 	return strings.ToLower(r)
 }
 
-// GetTag returns the Tag label for image from the ContainerRequest, defaults to UUID
+// GetTag returns the Tag label for image from the ContainerRequest, defaults to UUID,
+// or to the hash of the build if the built image is reused, see ShouldReuseBuiltImage
 func (c *ContainerRequest) GetTag() string {
 	t := c.FromDockerfile.Tag
 	if t == "" {
+		if c.ShouldReuseBuiltImage() {
+			hash, err := c.buildHash()
+			if err == nil {
+				return hash
+			}
+			// BuildOptions returns the error instead, as the image would not be reused
+			Logger.Printf("⚠️ Could not compute the hash of the build, using a random tag: %v", err)
+		}
 		return uuid.NewString()
 	}
 
@@ -272,7 +337,17 @@ func (c *ContainerRequest) GetAuthConfigs() map[string]registry.AuthConfig {
 
 // getAuthConfigsFromDockerfile returns the auth configs to be able to pull from an authenticated docker registry
 func getAuthConfigsFromDockerfile(c *ContainerRequest) map[string]registry.AuthConfig {
-	images, err := testcontainersdocker.ExtractImagesFromDockerfile(filepath.Join(c.Context, c.GetDockerfile()), c.GetBuildArgs())
+	var images []string
+	var err error
+	if c.hasContextFS() {
+		var dockerfile []byte
+		dockerfile, err = c.readContextFile(c.GetDockerfile())
+		if err == nil {
+			images, err = testcontainersdocker.ExtractImagesFromReader(bytes.NewReader(dockerfile), c.GetBuildArgs())
+		}
+	} else {
+		images, err = testcontainersdocker.ExtractImagesFromDockerfile(filepath.Join(c.Context, c.GetDockerfile()), c.GetBuildArgs())
+	}
 	if err != nil {
 		return map[string]registry.AuthConfig{}
 	}
@@ -291,17 +366,106 @@ func getAuthConfigsFromDockerfile(c *ContainerRequest) map[string]registry.AuthC
 }
 
 func (c *ContainerRequest) ShouldBuildImage() bool {
-	return c.FromDockerfile.Context != "" || c.FromDockerfile.ContextArchive != nil
+	return c.FromDockerfile.Context != "" || c.FromDockerfile.ContextArchive != nil || c.hasContextFS()
 }
 
 func (c *ContainerRequest) ShouldKeepBuiltImage() bool {
 	return c.FromDockerfile.KeepImage
 }
 
+// ShouldReuseBuiltImage returns true if the image is kept and its tag is not set, so it's tagged with the hash
+// of the build context, the Dockerfile and the build args, and reused instead of built again if it already exists.
+// The build context can be a directory, a file system or a set of in-memory files.
+// The image is not reused if the build context is an archive, or the build options are modified,
+// as the hash can't describe the build.
+func (c *ContainerRequest) ShouldReuseBuiltImage() bool {
+	return c.FromDockerfile.KeepImage &&
+		c.FromDockerfile.Tag == "" &&
+		(c.FromDockerfile.Context != "" || c.hasContextFS()) &&
+		c.FromDockerfile.ContextArchive == nil &&
+		c.FromDockerfile.BuildOptionsModifier == nil
+}
+
+// buildHash returns the hash of the files of the build context not excluded by the .dockerignore file,
+// of the Dockerfile, and of the build args and options which change the built image
+func (c *ContainerRequest) buildHash() (string, error) {
+	h := sha256.New()
+
+	if c.hasContextFS() {
+		// the archive of the file system is the same for the same files, and it includes the Dockerfile
+		archive, err := c.contextFSArchive()
+		if err != nil {
+			return "", err
+		}
+		h.Write(archive)
+		fmt.Fprintf(h, "dockerfile\x00%s\x00", c.GetDockerfile())
+	} else {
+		abs, err := filepath.Abs(c.Context)
+		if err != nil {
+			return "", fmt.Errorf("error getting absolute path: %w", err)
+		}
+
+		excluded, err := parseDockerIgnore(abs)
+		if err != nil {
+			return "", err
+		}
+
+		if err := hashBuildContext(h, abs, excluded); err != nil {
+			return "", err
+		}
+
+		dockerfile, err := os.ReadFile(filepath.Join(abs, c.GetDockerfile()))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "dockerfile\x00%s\x00%d\x00", c.GetDockerfile(), len(dockerfile))
+		h.Write(dockerfile)
+	}
+
+	hashBuildArgs(h, c.GetBuildArgs())
+	hashLabels(h, c.FromDockerfile.Labels)
+	fmt.Fprintf(h, "target\x00%s\x00", c.FromDockerfile.Target)
+	fmt.Fprintf(h, "platform\x00%s\x00", c.FromDockerfile.Platform)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// reusableImageTag returns the repo and tag of the built image to be reused, named after the hash of its build,
+// which can be looked up before archiving the build context
+func (c *ContainerRequest) reusableImageTag() (string, error) {
+	hash, err := c.buildHash()
+	if err != nil {
+		return "", fmt.Errorf("hash the build of the image: %w", err)
+	}
+
+	return fmt.Sprintf("%s:%s", c.GetRepo(), hash), nil
+}
+
 func (c *ContainerRequest) ShouldPrintBuildLog() bool {
 	return c.FromDockerfile.PrintBuildLog
 }
 
+// GetBuildSecrets returns the secrets exposed to the build
+func (c *ContainerRequest) GetBuildSecrets() []BuildSecret {
+	return c.FromDockerfile.Secrets
+}
+
+// GetBuildSSH returns the SSH agents or keys forwarded to the build
+func (c *ContainerRequest) GetBuildSSH() []BuildSSH {
+	return c.FromDockerfile.SSH
+}
+
+// GetBuildProgressConsumer returns the consumer of the progress of the build, if any
+func (c *ContainerRequest) GetBuildProgressConsumer() BuildProgressConsumer {
+	return c.FromDockerfile.BuildProgressConsumer
+}
+
+// ShouldUseBuildKit returns true if the image must be built with BuildKit,
+// because it's enabled or the build needs secrets or SSH forwarding
+func (c *ContainerRequest) ShouldUseBuildKit() bool {
+	return c.FromDockerfile.BuildKit || len(c.FromDockerfile.Secrets) > 0 || len(c.FromDockerfile.SSH) > 0
+}
+
 // BuildOptions returns the image build options when building a Docker image from a Dockerfile.
 // It will apply some defaults and finally call the BuildOptionsModifier from the FromDockerfile struct,
 // if set.
@@ -309,6 +473,14 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 	buildOptions := types.ImageBuildOptions{
 		Remove:      true,
 		ForceRemove: true,
+		Target:      c.FromDockerfile.Target,
+		CacheFrom:   c.FromDockerfile.CacheFrom,
+		Platform:    c.FromDockerfile.Platform,
+		Labels:      c.FromDockerfile.Labels,
+	}
+
+	if c.ShouldUseBuildKit() {
+		buildOptions.Version = types.BuilderBuildKit
 	}
 
 	if c.FromDockerfile.BuildOptionsModifier != nil {
@@ -337,7 +509,16 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 	}
 
 	// make sure the first tag is the one defined in the ContainerRequest
-	tag := fmt.Sprintf("%s:%s", c.GetRepo(), c.GetTag())
+	var tag string
+	if c.ShouldReuseBuiltImage() {
+		// a random tag would leave a new kept image behind on every run
+		tag, err = c.reusableImageTag()
+		if err != nil {
+			return buildOptions, err
+		}
+	} else {
+		tag = fmt.Sprintf("%s:%s", c.GetRepo(), c.GetTag())
+	}
 	if len(buildOptions.Tags) > 0 {
 		// prepend the tag
 		buildOptions.Tags = append([]string{tag}, buildOptions.Tags...)
@@ -349,7 +530,7 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 }
 
 func (c *ContainerRequest) validateContextAndImage() error {
-	if c.FromDockerfile.Context != "" && c.Image != "" {
+	if (c.FromDockerfile.Context != "" || c.hasContextFS()) && c.Image != "" {
 		return errors.New("you cannot specify both an Image and Context in a ContainerRequest")
 	}
 
@@ -357,7 +538,7 @@ func (c *ContainerRequest) validateContextAndImage() error {
 }
 
 func (c *ContainerRequest) validateContextOrImageIsSpecified() error {
-	if c.FromDockerfile.Context == "" && c.FromDockerfile.ContextArchive == nil && c.Image == "" {
+	if c.FromDockerfile.Context == "" && c.FromDockerfile.ContextArchive == nil && !c.hasContextFS() && c.Image == "" {
 		return errors.New("you must specify either a build context or an image")
 	}
 
@@ -7,10 +7,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -512,6 +515,111 @@ func TestParseDockerIgnore(t *testing.T) {
 	}
 }
 
+func TestBuildHash(t *testing.T) {
+	newContext := func(t *testing.T) string {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM alpine\nCOPY . /app\n"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".dockerignore"), []byte("logs\n"), 0o644))
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "logs"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "logs", "build.log"), []byte("first run"), 0o644))
+		return dir
+	}
+
+	hash := func(t *testing.T, fd FromDockerfile) string {
+		req := ContainerRequest{FromDockerfile: fd}
+		h, err := req.buildHash()
+		require.NoError(t, err)
+		return h
+	}
+
+	dir := newContext(t)
+	expected := hash(t, FromDockerfile{Context: dir})
+
+	t.Run("same build in another directory", func(t *testing.T) {
+		assert.Equal(t, expected, hash(t, FromDockerfile{Context: newContext(t)}))
+	})
+
+	t.Run("ignored file changed", func(t *testing.T) {
+		other := newContext(t)
+		require.NoError(t, os.WriteFile(filepath.Join(other, "logs", "build.log"), []byte("second run"), 0o644))
+		assert.Equal(t, expected, hash(t, FromDockerfile{Context: other}))
+	})
+
+	t.Run("file changed", func(t *testing.T) {
+		other := newContext(t)
+		require.NoError(t, os.WriteFile(filepath.Join(other, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644))
+		assert.NotEqual(t, expected, hash(t, FromDockerfile{Context: other}))
+	})
+
+	t.Run("file added", func(t *testing.T) {
+		other := newContext(t)
+		require.NoError(t, os.WriteFile(filepath.Join(other, "go.mod"), []byte("module app\n"), 0o644))
+		assert.NotEqual(t, expected, hash(t, FromDockerfile{Context: other}))
+	})
+
+	t.Run("build args changed", func(t *testing.T) {
+		empty := ""
+		withEmptyArg := hash(t, FromDockerfile{Context: dir, BuildArgs: map[string]*string{"FOO": &empty}})
+		withUnsetArg := hash(t, FromDockerfile{Context: dir, BuildArgs: map[string]*string{"FOO": nil}})
+
+		assert.NotEqual(t, expected, withEmptyArg)
+		assert.NotEqual(t, withEmptyArg, withUnsetArg)
+	})
+
+	t.Run("target changed", func(t *testing.T) {
+		assert.NotEqual(t, expected, hash(t, FromDockerfile{Context: dir, Target: "build"}))
+	})
+}
+
+func TestShouldReuseBuiltImage(t *testing.T) {
+	req := ContainerRequest{
+		FromDockerfile: FromDockerfile{
+			Context:   "testdata",
+			Repo:      "my-repo",
+			KeepImage: true,
+		},
+	}
+	require.True(t, req.ShouldReuseBuiltImage())
+
+	hash, err := req.buildHash()
+	require.NoError(t, err)
+	assert.Equal(t, "my-repo", req.GetRepo())
+	assert.Equal(t, hash, req.GetTag())
+
+	tag, err := req.reusableImageTag()
+	require.NoError(t, err)
+	assert.Equal(t, "my-repo:"+hash, tag)
+
+	req.Repo = ""
+	assert.Equal(t, reusableImageRepo, req.GetRepo())
+
+	req.BuildOptionsModifier = func(*types.ImageBuildOptions) {}
+	assert.False(t, req.ShouldReuseBuiltImage())
+
+	req.BuildOptionsModifier = nil
+	req.KeepImage = false
+	assert.False(t, req.ShouldReuseBuiltImage())
+	assert.NotEqual(t, hash, req.GetTag())
+}
+
+func TestBuildOptions_failsWithoutBuildHash(t *testing.T) {
+	req := ContainerRequest{
+		FromDockerfile: FromDockerfile{
+			Context:    "testdata",
+			Dockerfile: "missing.Dockerfile",
+			KeepImage:  true,
+		},
+	}
+	require.True(t, req.ShouldReuseBuiltImage())
+
+	_, err := req.reusableImageTag()
+	require.Error(t, err)
+
+	_, err = req.BuildOptions()
+	require.Error(t, err)
+}
+
 func ExampleGenericContainer_withSubstitutors() {
 	ctx := context.Background()
 
@@ -3,7 +3,6 @@ package testcontainers
 import (
 	"archive/tar"
 	"bufio"
-	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/binary"
@@ -11,10 +10,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -25,9 +26,8 @@ import (
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/errdefs"
-	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/go-connections/nat"
-	"github.com/moby/term"
+	"github.com/docker/go-units"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
 
 	tcexec "github.com/testcontainers/testcontainers-go/exec"
@@ -38,7 +38,10 @@ import (
 )
 
 // Implement interfaces
-var _ Container = (*DockerContainer)(nil)
+var (
+	_ Container                   = (*DockerContainer)(nil)
+	_ wait.NetworkNamespaceTarget = (*DockerContainer)(nil)
+)
 
 const (
 	Bridge        = "bridge" // Bridge network name (as well as driver)
@@ -47,6 +50,10 @@ const (
 	packagePath   = "github.com/testcontainers/testcontainers-go"
 
 	logStoppedForOutOfSyncMessage = "Stopping log consumer: Headers out of sync"
+
+	// netNSHelperImage is the image of the helper container used to run commands
+	// in the network namespace of containers without a shell
+	netNSHelperImage = "docker.io/busybox:1.36"
 )
 
 // DockerContainer represents a container started using Docker
@@ -69,6 +76,11 @@ type DockerContainer struct {
 	producerDone      chan bool
 	logger            Logging
 	lifecycleHooks    []ContainerLifecycleHooks
+
+	// netNSHelper is a container sharing the network namespace of this container,
+	// used to run commands when the container has no shell. It's created on demand.
+	netNSHelper    *DockerContainer
+	netNSHelperMtx sync.Mutex
 }
 
 // SetLogger sets the logger for the container
@@ -218,6 +230,10 @@ func (c *DockerContainer) Stop(ctx context.Context, timeout *time.Duration) erro
 		return err
 	}
 
+	// the helper is bound to the current network namespace of the container,
+	// and failing to terminate it must not keep the container running
+	helperErr := c.terminateNetworkNamespaceHelper(ctx)
+
 	var options container.StopOptions
 
 	if timeout != nil {
@@ -226,7 +242,7 @@ func (c *DockerContainer) Stop(ctx context.Context, timeout *time.Duration) erro
 	}
 
 	if err := c.provider.client.ContainerStop(ctx, c.ID, options); err != nil {
-		return err
+		return errors.Join(helperErr, err)
 	}
 	defer c.provider.Close()
 
@@ -234,10 +250,10 @@ func (c *DockerContainer) Stop(ctx context.Context, timeout *time.Duration) erro
 
 	err = c.stoppedHook(ctx)
 	if err != nil {
-		return err
+		return errors.Join(helperErr, err)
 	}
 
-	return nil
+	return helperErr
 }
 
 // Terminate is used to kill the container. It is usually triggered by as defer function.
@@ -260,17 +276,20 @@ func (c *DockerContainer) Terminate(ctx context.Context) error {
 		return err
 	}
 
+	// failing to terminate the helper must not leak the container
+	helperErr := c.terminateNetworkNamespaceHelper(ctx)
+
 	err = c.provider.client.ContainerRemove(ctx, c.GetContainerID(), types.ContainerRemoveOptions{
 		RemoveVolumes: true,
 		Force:         true,
 	})
 	if err != nil {
-		return err
+		return errors.Join(helperErr, err)
 	}
 
 	err = c.terminatedHook(ctx)
 	if err != nil {
-		return err
+		return errors.Join(helperErr, err)
 	}
 
 	if c.imageWasBuilt && !c.keepBuiltImage {
@@ -279,13 +298,13 @@ func (c *DockerContainer) Terminate(ctx context.Context) error {
 			PruneChildren: true,
 		})
 		if err != nil {
-			return err
+			return errors.Join(helperErr, err)
 		}
 	}
 
 	c.sessionID = ""
 	c.isRunning = false
-	return nil
+	return helperErr
 }
 
 // update container raw info
@@ -465,6 +484,58 @@ func (c *DockerContainer) NetworkAliases(ctx context.Context) (map[string][]stri
 	return a, nil
 }
 
+// ConnectToNetwork connects the container to the network, with the given aliases,
+// e.g. to move a running container to another network, simulating a migration of the service.
+func (c *DockerContainer) ConnectToNetwork(ctx context.Context, nw *DockerNetwork, aliases ...string) error {
+	return c.ConnectToNetworkWithIP(ctx, nw, "", aliases...)
+}
+
+// ConnectToNetworkWithIP connects the container to the network with a static IPv4 or IPv6 address,
+// and the given aliases. The address must belong to a subnet configured on the network.
+// If the address is empty, Docker assigns one.
+func (c *DockerContainer) ConnectToNetworkWithIP(ctx context.Context, nw *DockerNetwork, ip string, aliases ...string) error {
+	settings := &network.EndpointSettings{Aliases: aliases}
+	if ip != "" {
+		addr := net.ParseIP(ip)
+		if addr == nil {
+			return fmt.Errorf("invalid IP address %q", ip)
+		}
+
+		settings.IPAMConfig = &network.EndpointIPAMConfig{}
+		if addr.To4() != nil {
+			settings.IPAMConfig.IPv4Address = ip
+		} else {
+			settings.IPAMConfig.IPv6Address = ip
+		}
+	}
+
+	if err := c.networkConnectingHook(ctx, nw); err != nil {
+		return err
+	}
+
+	defer c.provider.Close()
+	if err := c.provider.client.NetworkConnect(ctx, nw.ID, c.ID, settings); err != nil {
+		return fmt.Errorf("connect container %s to network %s: %w", c.ID[:12], nw.Name, err)
+	}
+
+	return c.networkConnectedHook(ctx, nw)
+}
+
+// DisconnectFromNetwork disconnects the container from the network, e.g. to simulate a network partition.
+// The connections of the container on the network are closed.
+func (c *DockerContainer) DisconnectFromNetwork(ctx context.Context, nw *DockerNetwork) error {
+	if err := c.networkDisconnectingHook(ctx, nw); err != nil {
+		return err
+	}
+
+	defer c.provider.Close()
+	if err := c.provider.client.NetworkDisconnect(ctx, nw.ID, c.ID, false); err != nil {
+		return fmt.Errorf("disconnect container %s from network %s: %w", c.ID[:12], nw.Name, err)
+	}
+
+	return c.networkDisconnectedHook(ctx, nw)
+}
+
 func (c *DockerContainer) Exec(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error) {
 	cli := c.provider.client
 
@@ -512,6 +583,68 @@ func (c *DockerContainer) Exec(ctx context.Context, cmd []string, options ...tce
 	return exitCode, processOptions.Reader, nil
 }
 
+// ExecInNetworkNamespace executes a command in a helper container sharing the network namespace
+// of the container, so that the container network can be inspected from the inside even if the
+// container image has no shell, e.g. distroless images. The helper container is created on
+// first use and removed when the container is stopped or terminated.
+func (c *DockerContainer) ExecInNetworkNamespace(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error) {
+	helper, err := c.networkNamespaceHelper(ctx)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: creating network namespace helper failed", err)
+	}
+
+	return helper.Exec(ctx, cmd, options...)
+}
+
+// networkNamespaceHelper returns the helper container sharing the network namespace of the container,
+// starting it if needed
+func (c *DockerContainer) networkNamespaceHelper(ctx context.Context) (*DockerContainer, error) {
+	c.netNSHelperMtx.Lock()
+	defer c.netNSHelperMtx.Unlock()
+
+	if c.netNSHelper != nil {
+		return c.netNSHelper, nil
+	}
+
+	req := ContainerRequest{
+		Image:      netNSHelperImage,
+		Entrypoint: []string{"tail", "-f", "/dev/null"},
+		HostConfigModifier: func(hostConfig *container.HostConfig) {
+			hostConfig.NetworkMode = container.NetworkMode("container:" + c.ID)
+		},
+	}
+
+	helper, err := c.provider.CreateContainer(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := helper.Start(ctx); err != nil {
+		_ = helper.Terminate(ctx)
+		return nil, err
+	}
+
+	c.netNSHelper = helper.(*DockerContainer)
+
+	return c.netNSHelper, nil
+}
+
+// terminateNetworkNamespaceHelper removes the helper container sharing the network namespace
+// of the container, if it was created
+func (c *DockerContainer) terminateNetworkNamespaceHelper(ctx context.Context) error {
+	c.netNSHelperMtx.Lock()
+	defer c.netNSHelperMtx.Unlock()
+
+	if c.netNSHelper == nil {
+		return nil
+	}
+
+	err := c.netNSHelper.Terminate(ctx)
+	c.netNSHelper = nil
+
+	return err
+}
+
 type FileFromContainer struct {
 	underlying *io.ReadCloser
 	tarreader  *tar.Reader
@@ -735,10 +868,16 @@ type DockerNetwork struct {
 	Name              string
 	provider          *DockerProvider
 	terminationSignal chan bool
+	lifecycleHooks    []NetworkLifecycleHooks
 }
 
 // Remove is used to remove the network. It is usually triggered by as defer function.
 func (n *DockerNetwork) Remove(ctx context.Context) error {
+	err := n.removingHook(ctx)
+	if err != nil {
+		return err
+	}
+
 	select {
 	// close reaper if it was created
 	case n.terminationSignal <- true:
@@ -747,7 +886,76 @@ func (n *DockerNetwork) Remove(ctx context.Context) error {
 
 	defer n.provider.Close()
 
-	return n.provider.client.NetworkRemove(ctx, n.ID)
+	err = n.provider.client.NetworkRemove(ctx, n.ID)
+	if err != nil {
+		return err
+	}
+
+	return n.removedHook(ctx)
+}
+
+// Inspect returns the subnets, options and connected containers of the network,
+// with their IP addresses and aliases on the network
+func (n *DockerNetwork) Inspect(ctx context.Context) (*NetworkInfo, error) {
+	defer n.provider.Close()
+
+	resource, err := n.provider.client.NetworkInspect(ctx, n.ID, types.NetworkInspectOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// the aliases of the containers are only part of the container inspection
+	aliases := make(map[string][]string, len(resource.Containers))
+	for id := range resource.Containers {
+		inspect, err := n.provider.client.ContainerInspect(ctx, id)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				// the container was removed in the meantime
+				continue
+			}
+			return nil, err
+		}
+
+		if inspect.NetworkSettings == nil {
+			continue
+		}
+		if settings, ok := inspect.NetworkSettings.Networks[resource.Name]; ok {
+			aliases[id] = settings.Aliases
+		}
+	}
+
+	return newNetworkInfo(resource, aliases), nil
+}
+
+// Containers returns the containers connected to the network, sorted by name
+func (n *DockerNetwork) Containers(ctx context.Context) ([]NetworkContainer, error) {
+	info, err := n.Inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return info.Containers, nil
+}
+
+// IPOf returns the IP address of the container on the network, which is the IPv4 address,
+// or the IPv6 address if the container has no IPv4 address on the network
+func (n *DockerNetwork) IPOf(ctx context.Context, c Container) (string, error) {
+	defer n.provider.Close()
+
+	resource, err := n.provider.client.NetworkInspect(ctx, n.ID, types.NetworkInspectOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	endpoint, ok := resource.Containers[c.GetContainerID()]
+	if !ok {
+		return "", fmt.Errorf("container %s is not connected to network %s", c.GetContainerID(), n.Name)
+	}
+
+	if ip := stripPrefixLength(endpoint.IPv4Address); ip != "" {
+		return ip, nil
+	}
+	return stripPrefixLength(endpoint.IPv6Address), nil
 }
 
 // DockerProvider implements the ContainerProvider interface
@@ -780,9 +988,42 @@ func (p *DockerProvider) SetClient(c client.APIClient) {
 
 var _ ContainerProvider = (*DockerProvider)(nil)
 
-// BuildImage will build and image from context and Dockerfile, then return the tag
+// BuildImage will build and image from context and Dockerfile, then return the tag.
+// If the build fails, the returned error is a *BuildError, with the failed step and its output.
 func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (string, error) {
+	defer p.Close()
+
+	// the image is looked up before archiving the build context, which is only needed to build it
+	if r, ok := img.(reusableImageBuildInfo); ok && r.ShouldReuseBuiltImage() {
+		tag, err := r.reusableImageTag()
+		if err != nil {
+			return "", err
+		}
+		if _, _, err := p.client.ImageInspectWithRaw(ctx, tag); err == nil {
+			Logger.Printf("🔁 Reusing image %s, built from the same context", tag)
+			return tag, nil
+		}
+	}
+
 	buildOptions, err := img.BuildOptions()
+	if err != nil {
+		return "", err
+	}
+
+	var consumer BuildProgressConsumer
+	if bk, ok := img.(buildKitImageBuildInfo); ok {
+		consumer = bk.GetBuildProgressConsumer()
+
+		if buildOptions.Version == types.BuilderBuildKit {
+			sess, err := p.newBuildSession(ctx, bk.GetBuildSecrets(), bk.GetBuildSSH(), buildOptions.AuthConfigs)
+			if err != nil {
+				return "", err
+			}
+			defer sess.Close()
+
+			buildOptions.SessionID = sess.ID()
+		}
+	}
 
 	var buildError error
 	var resp types.ImageBuildResponse
@@ -797,32 +1038,21 @@ func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (st
 			Logger.Printf("Failed to build image: %s, will retry", err)
 			return err
 		}
-		defer p.Close()
 
 		return nil
 	}, backoff.WithContext(backoff.NewExponentialBackOff(), ctx))
 	if err != nil {
 		return "", errors.Join(buildError, err)
 	}
+	defer resp.Body.Close()
 
-	if img.ShouldPrintBuildLog() {
-		termFd, isTerm := term.GetFdInfo(os.Stderr)
-		err = jsonmessage.DisplayJSONMessagesStream(resp.Body, os.Stderr, termFd, isTerm, nil)
-		if err != nil {
-			return "", err
-		}
-	}
-
-	// need to read the response from Docker, I think otherwise the image
+	// need to read the response from Docker, otherwise the image
 	// might not finish building before continuing to execute here
-	buf := new(bytes.Buffer)
-	_, err = buf.ReadFrom(resp.Body)
+	err = newBuildOutput(consumer, img.ShouldPrintBuildLog()).read(resp.Body)
 	if err != nil {
 		return "", err
 	}
 
-	_ = resp.Body.Close()
-
 	// the first tag is the one we want
 	return buildOptions.Tags[0], nil
 }
@@ -929,43 +1159,56 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 			platform = &p
 		}
 
-		var shouldPullImage bool
-
+		policy := req.PullPolicy
 		if req.AlwaysPullImage {
-			shouldPullImage = true // If requested always attempt to pull image
-		} else {
-			image, _, err := p.client.ImageInspectWithRaw(ctx, imageName)
-			if err != nil {
-				if client.IsErrNotFound(err) {
-					shouldPullImage = true
-				} else {
-					return nil, err
-				}
-			}
-			if platform != nil && (image.Architecture != platform.Architecture || image.Os != platform.OS) {
-				shouldPullImage = true
-			}
+			policy = PullAlways() // If requested always attempt to pull image
+		} else if policy == nil {
+			policy = PullIfMissing()
 		}
 
-		if shouldPullImage {
-			pullOpt := types.ImagePullOptions{
-				Platform: req.ImagePlatform, // may be empty
-			}
+		// the credentials are only needed to query the registry, or to pull the image
+		var (
+			registryAuth     string
+			registryAuthOnce sync.Once
+		)
+		encodedRegistryAuth := func() string {
+			registryAuthOnce.Do(func() {
+				registryAuth = p.encodedImageAuth(ctx, imageName)
+			})
+			return registryAuth
+		}
 
-			registry, imageAuth, err := DockerImageAuth(ctx, imageName)
-			if err != nil {
-				p.Logger.Printf("Failed to get image auth for %s. Setting empty credentials for the image: %s. Error is:%s", registry, imageName, err)
-			} else {
-				// see https://github.com/docker/docs/blob/e8e1204f914767128814dca0ea008644709c117f/engine/api/sdk/examples.md?plain=1#L649-L657
-				encodedJSON, err := json.Marshal(imageAuth)
+		pullInfo := ImagePullInfo{
+			Image:    imageName,
+			Platform: platform,
+			remoteDigest: func(ctx context.Context) (string, error) {
+				distribution, err := p.client.DistributionInspect(ctx, imageName, encodedRegistryAuth())
 				if err != nil {
-					p.Logger.Printf("Failed to marshal image auth. Setting empty credentials for the image: %s. Error is:%s", imageName, err)
-				} else {
-					pullOpt.RegistryAuth = base64.URLEncoding.EncodeToString(encodedJSON)
+					return "", err
 				}
+				return distribution.Descriptor.Digest.String(), nil
+			},
+		}
+
+		image, _, err := p.client.ImageInspectWithRaw(ctx, imageName)
+		if err == nil {
+			pullInfo.Local = &image
+		} else if !client.IsErrNotFound(err) {
+			return nil, err
+		}
+
+		shouldPullImage, err := policy.ShouldPull(ctx, pullInfo)
+		if err != nil {
+			return nil, fmt.Errorf("pull policy %s for image %s: %w", policy.Description(), imageName, err)
+		}
+
+		if shouldPullImage {
+			pullOpt := types.ImagePullOptions{
+				Platform:     req.ImagePlatform, // may be empty
+				RegistryAuth: encodedRegistryAuth(),
 			}
 
-			if err := p.attemptToPullImage(ctx, imageName, pullOpt); err != nil {
+			if err := p.attemptToPullImage(ctx, imageName, pullOpt, req.PullProgressConsumer); err != nil {
 				return nil, err
 			}
 		}
@@ -979,14 +1222,15 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 	}
 
 	dockerInput := &container.Config{
-		Entrypoint: req.Entrypoint,
-		Image:      imageName,
-		Env:        env,
-		Labels:     req.Labels,
-		Cmd:        req.Cmd,
-		Hostname:   req.Hostname,
-		User:       req.User,
-		WorkingDir: req.WorkingDir,
+		Entrypoint:  req.Entrypoint,
+		Image:       imageName,
+		Env:         env,
+		Labels:      req.Labels,
+		Cmd:         req.Cmd,
+		Hostname:    req.Hostname,
+		User:        req.User,
+		WorkingDir:  req.WorkingDir,
+		Healthcheck: req.HealthCheck,
 	}
 
 	hostConfig := &container.HostConfig{
@@ -1030,7 +1274,11 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 							"🚧 Waiting for container id %s image: %s. Waiting for: %+v",
 							dockerContainer.ID[:12], dockerContainer.Image, dockerContainer.WaitingFor,
 						)
-						if err := dockerContainer.WaitingFor.WaitUntilReady(ctx, c); err != nil {
+						report, err := wait.WaitUntilReadyWithReport(ctx, dockerContainer.WaitingFor, c)
+						if hookErr := dockerContainer.readiedHook(ctx, report); hookErr != nil && err == nil {
+							err = hookErr
+						}
+						if err != nil {
 							return err
 						}
 					}
@@ -1043,6 +1291,23 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		},
 	}
 
+	created := false
+	if len(req.HostAccessPorts) > 0 {
+		sshd, sshdHooks, err := exposeHostPorts(ctx, &req, hostConfig)
+		if err != nil {
+			return nil, fmt.Errorf("expose host ports: %w", err)
+		}
+		defer func() {
+			// terminate the sidecar if the container could not be created
+			if !created {
+				_ = sshd.Terminate(ctx)
+			}
+		}()
+
+		// the sidecar hooks run after the default pre-create hook, which sets the extra hosts
+		defaultHooks = append(defaultHooks, sshdHooks)
+	}
+
 	// always prepend default lifecycle hooks to user-defined hooks
 	req.LifecycleHooks = append(defaultHooks, req.LifecycleHooks...)
 
@@ -1051,6 +1316,18 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		return nil, err
 	}
 
+	// Docker allows only one network to be specified during container creation, so the endpoint settings
+	// of the rest of the networks, e.g. set by the endpoint settings modifier, are used when connecting to them
+	extraEndpointSettings := map[string]*network.EndpointSettings{}
+	if len(req.Networks) > 0 {
+		for name, settings := range networkingConfig.EndpointsConfig {
+			if name != req.Networks[0] {
+				extraEndpointSettings[name] = settings
+				delete(networkingConfig.EndpointsConfig, name)
+			}
+		}
+	}
+
 	resp, err := p.client.ContainerCreate(ctx, dockerInput, hostConfig, networkingConfig, platform, req.Name)
 	if err != nil {
 		return nil, err
@@ -1066,6 +1343,9 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 				endpointSetting := network.EndpointSettings{
 					Aliases: req.NetworkAliases[n],
 				}
+				if settings, ok := extraEndpointSettings[n]; ok {
+					endpointSetting.IPAMConfig = settings.IPAMConfig
+				}
 				err = p.client.NetworkConnect(ctx, nw.ID, resp.ID, &endpointSetting)
 				if err != nil {
 					return nil, err
@@ -1095,6 +1375,7 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 
 	// Disable cleanup on success
 	termSignal = nil
+	created = true
 
 	return c, nil
 }
@@ -1158,9 +1439,28 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 	return dc, nil
 }
 
+// encodedImageAuth returns the encoded credentials of the registry of the image, or an empty string if they are not found
+func (p *DockerProvider) encodedImageAuth(ctx context.Context, imageName string) string {
+	registry, imageAuth, err := DockerImageAuth(ctx, imageName)
+	if err != nil {
+		p.Logger.Printf("Failed to get image auth for %s. Setting empty credentials for the image: %s. Error is:%s", registry, imageName, err)
+		return ""
+	}
+
+	// see https://github.com/docker/docs/blob/e8e1204f914767128814dca0ea008644709c117f/engine/api/sdk/examples.md?plain=1#L649-L657
+	encodedJSON, err := json.Marshal(imageAuth)
+	if err != nil {
+		p.Logger.Printf("Failed to marshal image auth. Setting empty credentials for the image: %s. Error is:%s", imageName, err)
+		return ""
+	}
+
+	return base64.URLEncoding.EncodeToString(encodedJSON)
+}
+
 // attemptToPullImage tries to pull the image while respecting the ctx cancellations.
 // Besides, if the image cannot be pulled due to ErrorNotFound then no need to retry but terminate immediately.
-func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pullOpt types.ImagePullOptions) error {
+// The progress of the pull is sent to the consumer, or logged if it's nil.
+func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pullOpt types.ImagePullOptions, consumer PullProgressConsumer) error {
 	var (
 		err  error
 		pull io.ReadCloser
@@ -1184,9 +1484,22 @@ func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pul
 	}
 	defer pull.Close()
 
+	logProgress := consumer == nil
+	if logProgress {
+		p.Logger.Printf("⏳ Pulling image %s", tag)
+		consumer = newPullProgressLogger(p.Logger)
+	}
+
 	// download of docker image finishes at EOF of the pull request
-	_, err = io.ReadAll(pull)
-	return err
+	progress, err := newPullOutput(tag, consumer).read(pull)
+	if err != nil {
+		return err
+	}
+
+	if logProgress {
+		p.Logger.Printf("✅ Pulled image %s: %d layers, %s", tag, progress.Layers, units.HumanSize(float64(progress.Total)))
+	}
+	return nil
 }
 
 // Health measure the healthiness of the provider. Right now we leverage the
@@ -1324,6 +1637,14 @@ func (p *DockerProvider) CreateNetwork(ctx context.Context, req NetworkRequest)
 		}
 	}()
 
+	// always prepend the default logging hook to user-defined hooks
+	req.LifecycleHooks = append([]NetworkLifecycleHooks{DefaultNetworkLoggingHook(p.Logger)}, req.LifecycleHooks...)
+
+	err = req.creatingHook(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	response, err := p.client.NetworkCreate(ctx, req.Name, nc)
 	if err != nil {
 		return &DockerNetwork{}, err
@@ -1335,6 +1656,12 @@ func (p *DockerProvider) CreateNetwork(ctx context.Context, req NetworkRequest)
 		Name:              req.Name,
 		terminationSignal: termSignal,
 		provider:          p,
+		lifecycleHooks:    req.LifecycleHooks,
+	}
+
+	err = n.createdHook(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// Disable cleanup on success
@@ -1343,6 +1670,81 @@ func (p *DockerProvider) CreateNetwork(ctx context.Context, req NetworkRequest)
 	return n, nil
 }
 
+// ReuseOrCreateNetwork returns the network with the name of the request if it exists, or creates it.
+// As Docker network names are global, the network is reused whatever test session created it,
+// the same way containers are reused, e.g. a network left by an earlier session.
+func (p *DockerProvider) ReuseOrCreateNetwork(ctx context.Context, req NetworkRequest) (Network, error) {
+	n, err := p.findNetworkByName(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if n != nil {
+		return p.reuseNetwork(ctx, req, n)
+	}
+
+	// another package of the test session may create the network in the meantime:
+	// the duplicate name is rejected, and the network created by the other package is reused
+	req.CheckDuplicate = true
+
+	created, err := p.CreateNetwork(ctx, req)
+	if err == nil {
+		return created, nil
+	}
+
+	n, findErr := p.findNetworkByName(ctx, req.Name)
+	if findErr != nil || n == nil {
+		return nil, err
+	}
+	return p.reuseNetwork(ctx, req, n)
+}
+
+// findNetworkByName returns the network with the given name, or nil if it doesn't exist
+func (p *DockerProvider) findNetworkByName(ctx context.Context, name string) (*types.NetworkResource, error) {
+	defer p.Close()
+
+	// the name filter matches the networks with the name as a substring
+	networks, err := p.client.NetworkList(ctx, types.NetworkListOptions{
+		Filters: filters.NewArgs(filters.Arg("name", name)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range networks {
+		if networks[i].Name == name {
+			return &networks[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// reuseNetwork returns an existing network, connected to the reaper of the test session
+func (p *DockerProvider) reuseNetwork(ctx context.Context, req NetworkRequest, n *types.NetworkResource) (*DockerNetwork, error) {
+	tcConfig := p.Config().Config
+
+	var termSignal chan bool
+	if !tcConfig.RyukDisabled {
+		r, err := reuseOrCreateReaper(context.WithValue(ctx, testcontainersdocker.DockerHostContextKey, p.host), testcontainerssession.SessionID(), p)
+		if err != nil {
+			return nil, fmt.Errorf("%w: creating network reaper failed", err)
+		}
+		termSignal, err = r.Connect()
+		if err != nil {
+			return nil, fmt.Errorf("%w: connecting to network reaper failed", err)
+		}
+	}
+
+	return &DockerNetwork{
+		ID:                n.ID,
+		Driver:            n.Driver,
+		Name:              n.Name,
+		terminationSignal: termSignal,
+		provider:          p,
+		lifecycleHooks:    append([]NetworkLifecycleHooks{DefaultNetworkLoggingHook(p.Logger)}, req.LifecycleHooks...),
+	}, nil
+}
+
 // GetNetwork returns the object representing the network identified by its name
 func (p *DockerProvider) GetNetwork(ctx context.Context, req NetworkRequest) (types.NetworkResource, error) {
 	networkResource, err := p.client.NetworkInspect(ctx, req.Name, types.NetworkInspectOptions{
@@ -1504,5 +1906,5 @@ func (p *DockerProvider) SaveImages(ctx context.Context, output string, images .
 
 // PullImage pulls image from registry
 func (p *DockerProvider) PullImage(ctx context.Context, image string) error {
-	return p.attemptToPullImage(ctx, image, types.ImagePullOptions{})
+	return p.attemptToPullImage(ctx, image, types.ImagePullOptions{}, nil)
 }
@@ -17,6 +17,7 @@ import (
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/go-units"
@@ -1873,6 +1874,84 @@ func TestNetworkModeWithContainerReference(t *testing.T) {
 	terminateContainerOnEnd(t, ctx, nginxB)
 }
 
+func TestDockerContainer_ConnectToNetwork(t *testing.T) {
+	ctx := context.Background()
+
+	n, err := GenericNetwork(ctx, GenericNetworkRequest{
+		NetworkRequest: NetworkRequest{
+			Name: "test-connect-to-network",
+			IPAM: &network.IPAM{
+				Config: []network.IPAMConfig{{Subnet: "10.211.0.0/24"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	nw := n.(*DockerNetwork)
+	t.Cleanup(func() {
+		require.NoError(t, nw.Remove(ctx))
+	})
+
+	var events []string
+	networkHook := func(event string) []ContainerNetworkHook {
+		return []ContainerNetworkHook{
+			func(ctx context.Context, c Container, nw *DockerNetwork) error {
+				events = append(events, event+" "+nw.Name)
+				return nil
+			},
+		}
+	}
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+			LifecycleHooks: []ContainerLifecycleHooks{
+				{
+					PreNetworkConnects:     networkHook("connecting"),
+					PostNetworkConnects:    networkHook("connected"),
+					PreNetworkDisconnects:  networkHook("disconnecting"),
+					PostNetworkDisconnects: networkHook("disconnected"),
+				},
+			},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	dc := c.(*DockerContainer)
+
+	// connectToNetwork {
+	err = dc.ConnectToNetworkWithIP(ctx, nw, "10.211.0.10", "web")
+	// }
+	require.NoError(t, err)
+
+	inspect, err := dc.inspectContainer(ctx)
+	require.NoError(t, err)
+	require.Contains(t, inspect.NetworkSettings.Networks, nw.Name)
+	assert.Equal(t, "10.211.0.10", inspect.NetworkSettings.Networks[nw.Name].IPAddress)
+	assert.Contains(t, inspect.NetworkSettings.Networks[nw.Name].Aliases, "web")
+
+	// disconnectFromNetwork {
+	err = dc.DisconnectFromNetwork(ctx, nw)
+	// }
+	require.NoError(t, err)
+
+	networks, err := dc.Networks(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, networks, nw.Name)
+
+	assert.Equal(t, []string{
+		"connecting " + nw.Name,
+		"connected " + nw.Name,
+		"disconnecting " + nw.Name,
+		"disconnected " + nw.Name,
+	}, events)
+
+	err = dc.ConnectToNetworkWithIP(ctx, nw, "not-an-ip")
+	require.EqualError(t, err, `invalid IP address "not-an-ip"`)
+}
+
 // creates a temporary dir in which the files will be extracted. Then it will compare the bytes of each file in the source with the bytes from the copied-from-container file
 func assertExtractedFiles(t *testing.T, ctx context.Context, container Container, hostFilePath string, containerFilePath string) {
 	// create all copied files into a temporary dir
@@ -2020,3 +2099,34 @@ func TestImageBuiltFromDockerfile_KeepBuiltImage(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerWithHealthCheck(t *testing.T) {
+	ctx := context.Background()
+
+	healthCheck := &container.HealthConfig{
+		Test:     []string{"CMD", "wget", "-q", "-O", "/dev/null", "http://localhost"},
+		Interval: time.Second,
+		Retries:  10,
+	}
+
+	req := GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:      nginxAlpineImage,
+			WaitingFor: wait.ForHealthCheck().WithStartupTimeout(30 * time.Second),
+		},
+		Started: true,
+	}
+	WithHealthCheck(healthCheck)(&req)
+
+	c, err := GenericContainer(ctx, req)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c.Terminate(ctx))
+	})
+
+	inspect, err := c.(*DockerContainer).inspectContainer(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, healthCheck, inspect.Config.Healthcheck)
+	assert.Equal(t, types.Healthy, inspect.State.Health.Status)
+}
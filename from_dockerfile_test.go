@@ -2,8 +2,10 @@ package testcontainers
 
 import (
 	"context"
+	"embed"
 	"fmt"
 	"io"
+	"io/fs"
 	"strings"
 	"testing"
 	"time"
@@ -216,3 +218,157 @@ func TestBuildImageFromDockerfile_TargetDoesNotExist(t *testing.T) {
 
 	assert.Contains(t, err.Error(), "failed to reach build target target-foo in Dockerfile")
 }
+
+func TestBuildImageFromDockerfile_TargetField(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context:    "testdata",
+				Dockerfile: "target.Dockerfile",
+				Target:     "target1",
+				BuildKit:   true,
+			},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c.Terminate(ctx))
+	})
+
+	r, err := c.Logs(ctx)
+	require.NoError(t, err)
+
+	logs, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	assert.Equal(t, "target1\n", string(logs))
+}
+
+func TestBuildImageFromDockerfile_Secrets(t *testing.T) {
+	ctx := context.Background()
+
+	consumer := &buildProgressCollector{}
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			// buildKitSecrets {
+			FromDockerfile: FromDockerfile{
+				Context:    "testdata",
+				Dockerfile: "secret.Dockerfile",
+				Secrets: []BuildSecret{
+					{ID: "token", Value: []byte("s3cr3t")},
+				},
+				BuildProgressConsumer: consumer,
+			},
+			// }
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c.Terminate(ctx))
+	})
+
+	r, err := c.Logs(ctx)
+	require.NoError(t, err)
+
+	logs, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	// the image holds the result of the check, not the secret
+	assert.Equal(t, "the secret matches\n", string(logs))
+	assert.NotEmpty(t, consumer.progress)
+}
+
+func TestBuildImageFromDockerfile_BuildError(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context:    "testdata",
+				Dockerfile: "secret.Dockerfile",
+				BuildKit:   true,
+			},
+		},
+		Started: true,
+	})
+
+	var buildErr *BuildError
+	require.ErrorAs(t, err, &buildErr)
+	assert.Contains(t, buildErr.Step, "RUN --mount=type=secret,id=token")
+}
+
+func TestBuildImageFromDockerfile_ReuseBuiltImage(t *testing.T) {
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	ctx := context.Background()
+
+	// reuseBuiltImage {
+	req := &ContainerRequest{
+		FromDockerfile: FromDockerfile{
+			Context:    "testdata",
+			Dockerfile: "echo.Dockerfile",
+			KeepImage:  true,
+		},
+	}
+	// }
+
+	tag, err := provider.BuildImage(ctx, req)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := provider.Client().ImageRemove(ctx, tag, types.ImageRemoveOptions{Force: true})
+		require.NoError(t, err)
+	})
+	assert.True(t, strings.HasPrefix(tag, reusableImageRepo+":"))
+
+	// the second build reuses the image, without building it again
+	consumer := &buildProgressCollector{}
+	req.BuildProgressConsumer = consumer
+
+	reused, err := provider.BuildImage(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, tag, reused)
+	assert.Empty(t, consumer.progress)
+}
+
+//go:embed testdata/contextfs
+var embeddedContext embed.FS
+
+func TestBuildImageFromDockerfile_ContextFS(t *testing.T) {
+	ctx := context.Background()
+
+	// buildFromContextFS {
+	contextFS, err := fs.Sub(embeddedContext, "testdata/contextfs")
+	require.NoError(t, err)
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				ContextFS: contextFS,
+				ContextFiles: map[string]ContextFile{
+					"greet.sh": {Content: []byte("echo this is from the in-memory file"), Mode: 0o755},
+				},
+			},
+		},
+		Started: true,
+	})
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c.Terminate(ctx))
+	})
+
+	r, err := c.Logs(ctx)
+	require.NoError(t, err)
+
+	logs, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	assert.Equal(t, "this is from the embedded build context\nthis is from the in-memory file\n", string(logs))
+}
@@ -11,8 +11,10 @@ import (
 )
 
 var (
-	reuseContainerMx  sync.Mutex
-	ErrReuseEmptyName = errors.New("with reuse option a container name mustn't be empty")
+	reuseContainerMx         sync.Mutex
+	reuseNetworkMx           sync.Mutex
+	ErrReuseEmptyName        = errors.New("with reuse option a container name mustn't be empty")
+	ErrReuseEmptyNetworkName = errors.New("with reuse option a network name mustn't be empty")
 )
 
 // GenericContainerRequest represents parameters to a generic container
@@ -22,6 +24,9 @@ type GenericContainerRequest struct {
 	ProviderType     ProviderType // which provider to use, Docker if empty
 	Logger           Logging      // provide a container specific Logging - use default global logger if empty
 	Reuse            bool         // reuse an existing container if it exists or create a new one. a container name mustn't be empty
+	// DependsOn declares the containers of the same ParallelContainerRequest which must be created,
+	// and meet their readiness conditions, before this container. It's only used by ParallelContainers.
+	DependsOn []ContainerDependency
 }
 
 // Deprecated: will be removed in the future.
@@ -29,16 +34,32 @@ type GenericContainerRequest struct {
 type GenericNetworkRequest struct {
 	NetworkRequest              // embedded request for provider
 	ProviderType   ProviderType // which provider to use, Docker if empty
+	Reuse          bool         // reuse a network of the test session with the same name if it exists or create a new one. a network name mustn't be empty
 }
 
 // Deprecated: use network.New instead
 // GenericNetwork creates a generic network with parameters
 func GenericNetwork(ctx context.Context, req GenericNetworkRequest) (Network, error) {
+	if req.Reuse && req.Name == "" {
+		return nil, ErrReuseEmptyNetworkName
+	}
+
 	provider, err := req.ProviderType.GetProvider()
 	if err != nil {
 		return nil, err
 	}
-	network, err := provider.CreateNetwork(ctx, req.NetworkRequest)
+
+	var network Network
+	if req.Reuse {
+		// we must protect the reusability of the network in the case it's invoked
+		// in a parallel execution, via t.Parallel()
+		reuseNetworkMx.Lock()
+		defer reuseNetworkMx.Unlock()
+
+		network, err = provider.ReuseOrCreateNetwork(ctx, req.NetworkRequest)
+	} else {
+		network, err = provider.CreateNetwork(ctx, req.NetworkRequest)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to create network", err)
 	}
@@ -96,3 +96,11 @@ func TestGenericReusableContainer(t *testing.T) {
 		})
 	}
 }
+
+func TestGenericReusableNetwork_emptyName(t *testing.T) {
+	//nolint:staticcheck
+	_, err := GenericNetwork(context.Background(), GenericNetworkRequest{
+		Reuse: true,
+	})
+	require.ErrorIs(t, err, ErrReuseEmptyNetworkName)
+}
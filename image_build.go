@@ -0,0 +1,428 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/pkg/jsonmessage"
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/auth"
+	"github.com/moby/buildkit/session/secrets"
+	"github.com/moby/buildkit/session/secrets/secretsprovider"
+	"github.com/moby/buildkit/session/sshforward/sshprovider"
+	"github.com/moby/patternmatcher"
+	digest "github.com/opencontainers/go-digest"
+	"google.golang.org/grpc"
+
+	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
+)
+
+// BuildSecret is a secret exposed to the RUN --mount=type=secret,id=<ID> instructions of a Dockerfile,
+// without being stored in the image. Only one of Value, File and Env must be set.
+type BuildSecret struct {
+	ID    string // the id of the secret in the Dockerfile
+	Value []byte // the value of the secret
+	File  string // the path to the file with the value of the secret
+	Env   string // the environment variable with the value of the secret
+}
+
+// BuildSSH forwards an SSH agent, or SSH keys, to the RUN --mount=type=ssh,id=<ID> instructions of a Dockerfile,
+// e.g. to clone private repositories
+type BuildSSH struct {
+	ID    string   // the id of the SSH forwarding in the Dockerfile, "default" if empty
+	Paths []string // the paths to the agent socket or to the keys, the SSH_AUTH_SOCK agent if empty
+}
+
+// BuildProgress is the progress of a step of an image build, e.g. "[2/3] RUN make",
+// reported to the BuildProgressConsumer of the request
+type BuildProgress struct {
+	Step      string // the name of the step
+	Started   bool   // the step started
+	Completed bool   // the step completed, with or without error
+	Cached    bool   // the step was not run, as its result is cached
+	Error     string // the error of the step, if it failed
+	Log       string // a part of the output of the step
+}
+
+// BuildProgressConsumer consumes the progress of the image builds, e.g. to report it to the user
+type BuildProgressConsumer interface {
+	Accept(BuildProgress)
+}
+
+// BuildError is returned when the build of an image fails, with the message of the builder,
+// and the step which failed, with its last lines of output, if available
+type BuildError struct {
+	Message string
+	Step    string
+	Log     []string
+}
+
+func (e *BuildError) Error() string {
+	if e.Step == "" {
+		return "build image: " + e.Message
+	}
+	return fmt.Sprintf("build image: %s: %s", e.Step, e.Message)
+}
+
+// maxBuildErrorLogLines is the number of lines of output of the failed step kept in a BuildError
+const maxBuildErrorLogLines = 10
+
+// buildKitTraceID is the ID of the messages of the build output holding the BuildKit progress
+const buildKitTraceID = "moby.buildkit.trace"
+
+// newBuildSession creates the BuildKit session sharing the secrets, the SSH agents and the registry
+// credentials of the build with the builder, and runs it in the background until it's closed
+func (p *DockerProvider) newBuildSession(ctx context.Context, buildSecrets []BuildSecret, buildSSH []BuildSSH, authConfigs map[string]registry.AuthConfig) (*session.Session, error) {
+	sess, err := session.NewSession(ctx, "testcontainers", "")
+	if err != nil {
+		return nil, fmt.Errorf("create build session: %w", err)
+	}
+
+	sess.Allow(&buildAuthProvider{authConfigs: authConfigs})
+	sess.Allow(secretsprovider.NewSecretProvider(buildSecretStore(buildSecrets)))
+
+	if len(buildSSH) > 0 {
+		configs := make([]sshprovider.AgentConfig, 0, len(buildSSH))
+		for _, s := range buildSSH {
+			id := s.ID
+			if id == "" {
+				id = "default"
+			}
+			configs = append(configs, sshprovider.AgentConfig{ID: id, Paths: s.Paths})
+		}
+
+		ssh, err := sshprovider.NewSSHAgentProvider(configs)
+		if err != nil {
+			_ = sess.Close()
+			return nil, fmt.Errorf("forward ssh: %w", err)
+		}
+		sess.Allow(ssh)
+	}
+
+	cli := p.client
+	go func() {
+		_ = sess.Run(ctx, func(ctx context.Context, proto string, meta map[string][]string) (net.Conn, error) {
+			return cli.DialHijack(ctx, "/session", proto, meta)
+		})
+	}()
+
+	return sess, nil
+}
+
+// buildSecretStore serves the secrets of the build by id
+type buildSecretStore []BuildSecret
+
+func (s buildSecretStore) GetSecret(_ context.Context, id string) ([]byte, error) {
+	for _, secret := range s {
+		if secret.ID != id {
+			continue
+		}
+
+		switch {
+		case secret.Value != nil:
+			return secret.Value, nil
+		case secret.File != "":
+			return os.ReadFile(secret.File)
+		case secret.Env != "":
+			if v, ok := os.LookupEnv(secret.Env); ok {
+				return []byte(v), nil
+			}
+			return nil, fmt.Errorf("secret %s: environment variable %s is not set: %w", id, secret.Env, secrets.ErrNotFound)
+		}
+	}
+
+	return nil, fmt.Errorf("secret %s: %w", id, secrets.ErrNotFound)
+}
+
+// buildAuthProvider shares the registry credentials of the build with BuildKit, which falls back
+// to fetching the tokens itself as the token methods are not implemented
+type buildAuthProvider struct {
+	auth.UnimplementedAuthServer
+	authConfigs map[string]registry.AuthConfig
+}
+
+func (ap *buildAuthProvider) Register(server *grpc.Server) {
+	auth.RegisterAuthServer(server, ap)
+}
+
+func (ap *buildAuthProvider) Credentials(_ context.Context, req *auth.CredentialsRequest) (*auth.CredentialsResponse, error) {
+	host := req.Host
+	if host == "registry-1.docker.io" || host == "docker.io" {
+		host = testcontainersdocker.IndexDockerIO
+	}
+
+	cfg, ok := getRegistryAuth(host, ap.authConfigs)
+	if !ok {
+		return &auth.CredentialsResponse{}, nil
+	}
+
+	if cfg.IdentityToken != "" {
+		return &auth.CredentialsResponse{Secret: cfg.IdentityToken}, nil
+	}
+	return &auth.CredentialsResponse{Username: cfg.Username, Secret: cfg.Password}, nil
+}
+
+// buildOutput reads the output of a build, reporting its progress to the consumer,
+// and printing it if required, until the build finishes. It returns a BuildError if the build fails.
+type buildOutput struct {
+	consumer BuildProgressConsumer
+	printLog bool
+
+	steps       map[digest.Digest]string // names of the BuildKit steps
+	currentStep string
+	stepLogs    map[string][]string // last lines of output, by step
+	failedStep  string
+}
+
+func newBuildOutput(consumer BuildProgressConsumer, printLog bool) *buildOutput {
+	return &buildOutput{
+		consumer: consumer,
+		printLog: printLog,
+		steps:    map[digest.Digest]string{},
+		stepLogs: map[string][]string{},
+	}
+}
+
+// read decodes the JSON messages of the build output
+func (o *buildOutput) read(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	for {
+		var jm jsonmessage.JSONMessage
+		if err := dec.Decode(&jm); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("read build output: %w", err)
+		}
+
+		if jm.Error != nil {
+			return o.buildError(jm.Error.Message)
+		}
+
+		if jm.ID == buildKitTraceID {
+			if err := o.readTrace(jm.Aux); err != nil {
+				return err
+			}
+			continue
+		}
+
+		o.readLegacy(jm)
+		if o.printLog {
+			_ = jm.Display(os.Stderr, false)
+		}
+	}
+}
+
+// readLegacy reports the progress of the legacy builder, which outputs the steps, e.g. "Step 1/3 : FROM alpine",
+// followed by their output
+func (o *buildOutput) readLegacy(jm jsonmessage.JSONMessage) {
+	if jm.Stream == "" {
+		return
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(jm.Stream, "\n"), "\n") {
+		if strings.HasPrefix(line, "Step ") {
+			if _, step, ok := strings.Cut(line, " : "); ok {
+				o.currentStep = step
+				o.report(BuildProgress{Step: step, Started: true})
+				continue
+			}
+		}
+
+		o.report(BuildProgress{Step: o.currentStep, Log: line})
+	}
+}
+
+// readTrace reports the progress of BuildKit, encoded in the aux field of the messages
+func (o *buildOutput) readTrace(aux *json.RawMessage) error {
+	if aux == nil {
+		return nil
+	}
+
+	var data []byte
+	if err := json.Unmarshal(*aux, &data); err != nil {
+		return fmt.Errorf("read build progress: %w", err)
+	}
+
+	var status controlapi.StatusResponse
+	if err := status.Unmarshal(data); err != nil {
+		return fmt.Errorf("read build progress: %w", err)
+	}
+
+	for _, v := range status.Vertexes {
+		o.steps[v.Digest] = v.Name
+		progress := BuildProgress{
+			Step:      v.Name,
+			Started:   v.Started != nil,
+			Completed: v.Completed != nil,
+			Cached:    v.Cached,
+			Error:     v.Error,
+		}
+		if v.Error != "" {
+			o.failedStep = v.Name
+		}
+		o.report(progress)
+
+		if o.printLog {
+			printBuildKitStep(progress)
+		}
+	}
+
+	for _, l := range status.Logs {
+		step := o.steps[l.Vertex]
+		for _, line := range strings.Split(strings.TrimRight(string(l.Msg), "\n"), "\n") {
+			o.report(BuildProgress{Step: step, Log: line})
+			if o.printLog {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", step, line)
+			}
+		}
+	}
+
+	return nil
+}
+
+// printBuildKitStep prints the state of a BuildKit step in plain text
+func printBuildKitStep(progress BuildProgress) {
+	switch {
+	case progress.Error != "":
+		fmt.Fprintf(os.Stderr, "%s ERROR: %s\n", progress.Step, progress.Error)
+	case progress.Cached:
+		fmt.Fprintf(os.Stderr, "%s CACHED\n", progress.Step)
+	case progress.Completed:
+		fmt.Fprintf(os.Stderr, "%s DONE\n", progress.Step)
+	case progress.Started:
+		fmt.Fprintln(os.Stderr, progress.Step)
+	}
+}
+
+// report sends the progress to the consumer, keeping the last lines of output of each step
+func (o *buildOutput) report(progress BuildProgress) {
+	if progress.Log != "" {
+		logs := append(o.stepLogs[progress.Step], progress.Log)
+		if len(logs) > maxBuildErrorLogLines {
+			logs = logs[len(logs)-maxBuildErrorLogLines:]
+		}
+		o.stepLogs[progress.Step] = logs
+	}
+
+	if o.consumer != nil {
+		o.consumer.Accept(progress)
+	}
+}
+
+// buildError returns the error of the build, with the failed step and its last lines of output
+func (o *buildOutput) buildError(message string) *BuildError {
+	step := o.failedStep
+	if step == "" {
+		// the legacy builder fails in the current step
+		step = o.currentStep
+	}
+
+	return &BuildError{
+		Message: message,
+		Step:    step,
+		Log:     o.stepLogs[step],
+	}
+}
+
+// reusableImageRepo is the repo of the images tagged with the hash of their build, see ContainerRequest.ShouldReuseBuiltImage
+const reusableImageRepo = "testcontainers-build"
+
+// hashBuildContext writes the paths, modes and contents of the files of the build context to the hash,
+// in lexical order, skipping the files excluded by the patterns of the .dockerignore file
+func hashBuildContext(w io.Writer, dir string, excluded []string) error {
+	pm, err := patternmatcher.New(excluded)
+	if err != nil {
+		return fmt.Errorf("error parsing .dockerignore: %w", err)
+	}
+
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+
+		skip, err := pm.MatchesOrParentMatches(rel)
+		if err != nil {
+			return err
+		}
+		if skip {
+			// the files of an excluded directory can't be included again without exclusion patterns
+			if d.IsDir() && !pm.Exclusions() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "file\x00%s\x00%o\x00", filepath.ToSlash(rel), info.Mode())
+
+		switch {
+		case info.Mode()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "%s\x00", target)
+		case info.Mode().IsRegular():
+			fmt.Fprintf(w, "%d\x00", info.Size())
+
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			if _, err := io.Copy(w, f); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// hashBuildArgs writes the build args to the hash in lexical order, telling the unset values from the empty ones
+func hashBuildArgs(w io.Writer, args map[string]*string) {
+	for _, k := range sortedKeys(args) {
+		if v := args[k]; v != nil {
+			fmt.Fprintf(w, "arg\x00%s\x00=%s\x00", k, *v)
+		} else {
+			fmt.Fprintf(w, "arg\x00%s\x00", k)
+		}
+	}
+}
+
+// hashLabels writes the labels of the image to the hash in lexical order
+func hashLabels(w io.Writer, labels map[string]string) {
+	for _, k := range sortedKeys(labels) {
+		fmt.Fprintf(w, "label\x00%s\x00%s\x00", k, labels[k])
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,222 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/moby/buildkit/session/secrets"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type buildProgressCollector struct {
+	progress []BuildProgress
+}
+
+func (c *buildProgressCollector) Accept(p BuildProgress) {
+	c.progress = append(c.progress, p)
+}
+
+// buildStream encodes the JSON messages as the build output of the Docker daemon
+func buildStream(t *testing.T, messages ...jsonmessage.JSONMessage) *strings.Reader {
+	t.Helper()
+
+	var sb strings.Builder
+	enc := json.NewEncoder(&sb)
+	for _, m := range messages {
+		require.NoError(t, enc.Encode(m))
+	}
+	return strings.NewReader(sb.String())
+}
+
+// buildKitTrace encodes the BuildKit status as the aux field of a build output message
+func buildKitTrace(t *testing.T, status *controlapi.StatusResponse) jsonmessage.JSONMessage {
+	t.Helper()
+
+	data, err := status.Marshal()
+	require.NoError(t, err)
+
+	aux, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	raw := json.RawMessage(aux)
+	return jsonmessage.JSONMessage{ID: buildKitTraceID, Aux: &raw}
+}
+
+func TestBuildOutput_legacy(t *testing.T) {
+	consumer := &buildProgressCollector{}
+
+	r := buildStream(t,
+		jsonmessage.JSONMessage{Stream: "Step 1/2 : FROM alpine\n"},
+		jsonmessage.JSONMessage{Stream: " ---> 3cc203321400\n"},
+		jsonmessage.JSONMessage{Stream: "Step 2/2 : RUN exit 1\n"},
+		jsonmessage.JSONMessage{Stream: "something went wrong\n"},
+		jsonmessage.JSONMessage{Error: &jsonmessage.JSONError{Code: 1, Message: "The command '/bin/sh -c exit 1' returned a non-zero code: 1"}},
+	)
+
+	err := newBuildOutput(consumer, false).read(r)
+
+	var buildErr *BuildError
+	require.ErrorAs(t, err, &buildErr)
+	assert.Equal(t, "RUN exit 1", buildErr.Step)
+	assert.Equal(t, []string{"something went wrong"}, buildErr.Log)
+	assert.Equal(t, "build image: RUN exit 1: The command '/bin/sh -c exit 1' returned a non-zero code: 1", err.Error())
+
+	assert.Equal(t, []BuildProgress{
+		{Step: "FROM alpine", Started: true},
+		{Step: "FROM alpine", Log: " ---> 3cc203321400"},
+		{Step: "RUN exit 1", Started: true},
+		{Step: "RUN exit 1", Log: "something went wrong"},
+	}, consumer.progress)
+}
+
+func TestBuildOutput_buildKit(t *testing.T) {
+	consumer := &buildProgressCollector{}
+	started := time.Now()
+	from := digest.FromString("from")
+	run := digest.FromString("run")
+
+	r := buildStream(t,
+		buildKitTrace(t, &controlapi.StatusResponse{
+			Vertexes: []*controlapi.Vertex{
+				{Digest: from, Name: "[1/2] FROM docker.io/library/alpine", Started: &started, Completed: &started, Cached: true},
+				{Digest: run, Name: "[2/2] RUN make", Started: &started},
+			},
+		}),
+		buildKitTrace(t, &controlapi.StatusResponse{
+			Logs: []*controlapi.VertexLog{
+				{Vertex: run, Msg: []byte("make: *** No targets specified\n")},
+			},
+		}),
+		buildKitTrace(t, &controlapi.StatusResponse{
+			Vertexes: []*controlapi.Vertex{
+				{Digest: run, Name: "[2/2] RUN make", Started: &started, Completed: &started, Error: "exit code: 2"},
+			},
+		}),
+		jsonmessage.JSONMessage{Error: &jsonmessage.JSONError{Message: "process \"/bin/sh -c make\" did not complete successfully: exit code: 2"}},
+	)
+
+	err := newBuildOutput(consumer, false).read(r)
+
+	var buildErr *BuildError
+	require.ErrorAs(t, err, &buildErr)
+	assert.Equal(t, "[2/2] RUN make", buildErr.Step)
+	assert.Equal(t, []string{"make: *** No targets specified"}, buildErr.Log)
+
+	assert.Equal(t, []BuildProgress{
+		{Step: "[1/2] FROM docker.io/library/alpine", Started: true, Completed: true, Cached: true},
+		{Step: "[2/2] RUN make", Started: true},
+		{Step: "[2/2] RUN make", Log: "make: *** No targets specified"},
+		{Step: "[2/2] RUN make", Started: true, Completed: true, Error: "exit code: 2"},
+	}, consumer.progress)
+}
+
+func TestBuildOutput_keepsLastLogLines(t *testing.T) {
+	var messages []jsonmessage.JSONMessage
+	messages = append(messages, jsonmessage.JSONMessage{Stream: "Step 1/1 : RUN seq 20\n"})
+	for i := 1; i <= 20; i++ {
+		messages = append(messages, jsonmessage.JSONMessage{Stream: strings.Repeat("x", i) + "\n"})
+	}
+	messages = append(messages, jsonmessage.JSONMessage{Error: &jsonmessage.JSONError{Message: "failed"}})
+
+	err := newBuildOutput(nil, false).read(buildStream(t, messages...))
+
+	var buildErr *BuildError
+	require.ErrorAs(t, err, &buildErr)
+	require.Len(t, buildErr.Log, maxBuildErrorLogLines)
+	assert.Equal(t, strings.Repeat("x", 20), buildErr.Log[maxBuildErrorLogLines-1])
+}
+
+func TestBuildSecretStore(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(file, []byte("from-file"), 0o600))
+	t.Setenv("BUILD_SECRET_TOKEN", "from-env")
+
+	store := buildSecretStore{
+		{ID: "value", Value: []byte("from-value")},
+		{ID: "file", File: file},
+		{ID: "env", Env: "BUILD_SECRET_TOKEN"},
+		{ID: "unset", Env: "BUILD_SECRET_UNSET"},
+	}
+
+	ctx := context.Background()
+	for id, expected := range map[string]string{"value": "from-value", "file": "from-file", "env": "from-env"} {
+		v, err := store.GetSecret(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(v))
+	}
+
+	_, err := store.GetSecret(ctx, "unset")
+	assert.True(t, errors.Is(err, secrets.ErrNotFound))
+
+	_, err = store.GetSecret(ctx, "missing")
+	assert.True(t, errors.Is(err, secrets.ErrNotFound))
+}
+
+func TestBuildOptions_buildKit(t *testing.T) {
+	t.Run("legacy builder by default", func(t *testing.T) {
+		req := ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context:   "testdata",
+				Target:    "target1",
+				CacheFrom: []string{"cache:latest"},
+				Platform:  "linux/amd64",
+				Labels:    map[string]string{"app": "test"},
+			},
+		}
+
+		opts, err := req.BuildOptions()
+		require.NoError(t, err)
+
+		assert.Empty(t, opts.Version)
+		assert.Equal(t, "target1", opts.Target)
+		assert.Equal(t, []string{"cache:latest"}, opts.CacheFrom)
+		assert.Equal(t, "linux/amd64", opts.Platform)
+		assert.Equal(t, map[string]string{"app": "test"}, opts.Labels)
+	})
+
+	t.Run("buildkit with secrets", func(t *testing.T) {
+		req := ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context:  "testdata",
+				Secrets:  []BuildSecret{{ID: "token", Value: []byte("secret")}},
+				Platform: "linux/arm64",
+			},
+		}
+
+		opts, err := req.BuildOptions()
+		require.NoError(t, err)
+
+		assert.Equal(t, types.BuilderBuildKit, opts.Version)
+		assert.Equal(t, "linux/arm64", opts.Platform)
+	})
+
+	t.Run("modifier overrides the target", func(t *testing.T) {
+		req := ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context:  "testdata",
+				Target:   "target1",
+				BuildKit: true,
+				BuildOptionsModifier: func(opts *types.ImageBuildOptions) {
+					opts.Target = "target2"
+				},
+			},
+		}
+
+		opts, err := req.BuildOptions()
+		require.NoError(t, err)
+
+		assert.Equal(t, types.BuilderBuildKit, opts.Version)
+		assert.Equal(t, "target2", opts.Target)
+	})
+}
@@ -0,0 +1,280 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/go-units"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// PullPolicy decides whether the image of a container is pulled before the container is created
+type PullPolicy interface {
+	// Description returns the name of the policy, useful to be printed in logs
+	Description() string
+	// ShouldPull returns true if the image must be pulled
+	ShouldPull(ctx context.Context, image ImagePullInfo) (bool, error)
+}
+
+// ImagePullInfo describes the image of a container, for its PullPolicy to decide whether to pull it
+type ImagePullInfo struct {
+	Image    string              // the name of the image
+	Local    *types.ImageInspect // the local image, nil if it doesn't exist
+	Platform *specs.Platform     // the platform of the image requested for the container, if any
+
+	remoteDigest func(ctx context.Context) (string, error)
+}
+
+// IsMissing returns true if the image doesn't exist locally, or it's not built for the requested platform
+func (i ImagePullInfo) IsMissing() bool {
+	if i.Local == nil {
+		return true
+	}
+
+	return i.Platform != nil && (i.Local.Architecture != i.Platform.Architecture || i.Local.Os != i.Platform.OS)
+}
+
+// RemoteDigest returns the digest of the image in its registry, e.g. "sha256:…", querying the registry
+func (i ImagePullInfo) RemoteDigest(ctx context.Context) (string, error) {
+	if i.remoteDigest == nil {
+		return "", errors.New("the digest of the image in the registry is not available")
+	}
+	return i.remoteDigest(ctx)
+}
+
+// PullAlways returns a policy which always pulls the image
+func PullAlways() PullPolicy {
+	return pullAlways{}
+}
+
+type pullAlways struct{}
+
+func (pullAlways) Description() string {
+	return "always"
+}
+
+func (pullAlways) ShouldPull(context.Context, ImagePullInfo) (bool, error) {
+	return true, nil
+}
+
+// PullIfMissing returns a policy which pulls the image if it doesn't exist locally,
+// or it's not built for the requested platform. It's the default policy.
+func PullIfMissing() PullPolicy {
+	return pullIfMissing{}
+}
+
+type pullIfMissing struct{}
+
+func (pullIfMissing) Description() string {
+	return "missing"
+}
+
+func (pullIfMissing) ShouldPull(_ context.Context, image ImagePullInfo) (bool, error) {
+	return image.IsMissing(), nil
+}
+
+// PullIfOlderThan returns a policy which pulls the image if it's missing, or it was pulled, or tagged,
+// more than maxAge ago, e.g. to refresh the latest tags once a day with PullIfOlderThan(24*time.Hour)
+func PullIfOlderThan(maxAge time.Duration) PullPolicy {
+	return pullIfOlderThan{maxAge: maxAge, now: time.Now}
+}
+
+type pullIfOlderThan struct {
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+func (p pullIfOlderThan) Description() string {
+	return "older than " + p.maxAge.String()
+}
+
+func (p pullIfOlderThan) ShouldPull(_ context.Context, image ImagePullInfo) (bool, error) {
+	if image.IsMissing() {
+		return true, nil
+	}
+
+	updated := image.Local.Metadata.LastTagTime
+	if updated.IsZero() {
+		// the images loaded from an archive are not tagged, so the creation time is the best estimate
+		created, err := time.Parse(time.RFC3339Nano, image.Local.Created)
+		if err != nil {
+			return false, fmt.Errorf("invalid creation time of image %s: %w", image.Image, err)
+		}
+		updated = created
+	}
+
+	return p.now().Sub(updated) > p.maxAge, nil
+}
+
+// PullIfDigestMismatch returns a policy which pulls the image if it's missing, or its digest in the registry
+// differs from the local one, e.g. because the tag was pushed again. It queries the registry for every container.
+func PullIfDigestMismatch() PullPolicy {
+	return pullIfDigestMismatch{}
+}
+
+type pullIfDigestMismatch struct{}
+
+func (pullIfDigestMismatch) Description() string {
+	return "digest mismatch"
+}
+
+func (pullIfDigestMismatch) ShouldPull(ctx context.Context, image ImagePullInfo) (bool, error) {
+	if image.IsMissing() {
+		return true, nil
+	}
+
+	remote, err := image.RemoteDigest(ctx)
+	if err != nil {
+		return false, fmt.Errorf("get digest of image %s: %w", image.Image, err)
+	}
+
+	for _, repoDigest := range image.Local.RepoDigests {
+		if _, digest, ok := strings.Cut(repoDigest, "@"); ok && digest == remote {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// PullProgress is the progress of the pull of an image, aggregated from the progress of its layers
+type PullProgress struct {
+	Image           string // the name of the image
+	Layers          int    // the number of layers of the image known so far
+	CompletedLayers int    // the number of layers downloaded and extracted, or already existing
+	Current         int64  // the downloaded bytes of the layers
+	Total           int64  // the size of the layers, as far as it's known
+	Layer           string // the id of the layer whose progress changed
+	Status          string // the status of the layer, e.g. "Downloading"
+}
+
+// PullProgressConsumer consumes the progress of the image pulls, e.g. to report it to the user
+type PullProgressConsumer interface {
+	Accept(PullProgress)
+}
+
+// pullProgressLogInterval is the minimum time between the logs of the progress of a pull
+const pullProgressLogInterval = 5 * time.Second
+
+// pullProgressLogger logs the progress of the pulls, at most once every pullProgressLogInterval
+type pullProgressLogger struct {
+	logger Logging
+	logged time.Time
+	now    func() time.Time
+}
+
+func newPullProgressLogger(logger Logging) *pullProgressLogger {
+	return &pullProgressLogger{logger: logger, logged: time.Now(), now: time.Now}
+}
+
+func (l *pullProgressLogger) Accept(p PullProgress) {
+	now := l.now()
+	if now.Sub(l.logged) < pullProgressLogInterval {
+		return
+	}
+
+	l.logged = now
+	l.logger.Printf("⏳ Pulling image %s: %d/%d layers, %s/%s", p.Image, p.CompletedLayers, p.Layers, units.HumanSize(float64(p.Current)), units.HumanSize(float64(p.Total)))
+}
+
+// pullLayer is the progress of the pull of a layer
+type pullLayer struct {
+	current   int64
+	total     int64
+	completed bool
+}
+
+// pullOutput reads the output of a pull, aggregating the progress of the layers, until the pull finishes
+type pullOutput struct {
+	image    string
+	consumer PullProgressConsumer
+	layers   map[string]*pullLayer
+}
+
+func newPullOutput(image string, consumer PullProgressConsumer) *pullOutput {
+	return &pullOutput{
+		image:    image,
+		consumer: consumer,
+		layers:   map[string]*pullLayer{},
+	}
+}
+
+// read decodes the JSON messages of the pull output, returning the error of the pull, if any
+func (o *pullOutput) read(r io.Reader) (PullProgress, error) {
+	dec := json.NewDecoder(r)
+	for {
+		var jm jsonmessage.JSONMessage
+		if err := dec.Decode(&jm); err != nil {
+			if errors.Is(err, io.EOF) {
+				return o.progress("", ""), nil
+			}
+			return o.progress("", ""), fmt.Errorf("read pull output of image %s: %w", o.image, err)
+		}
+
+		if jm.Error != nil {
+			return o.progress("", ""), fmt.Errorf("pull image %s: %s", o.image, jm.Error.Message)
+		}
+
+		if o.update(jm) && o.consumer != nil {
+			o.consumer.Accept(o.progress(jm.ID, jm.Status))
+		}
+	}
+}
+
+// update updates the progress of the layer of the message, returning false if the message is not about a layer,
+// e.g. "Pulling from library/alpine" or "Digest: sha256:…"
+func (o *pullOutput) update(jm jsonmessage.JSONMessage) bool {
+	switch jm.Status {
+	case "Pulling fs layer", "Waiting", "Downloading", "Verifying Checksum", "Download complete", "Extracting", "Pull complete", "Already exists":
+	default:
+		return false
+	}
+
+	layer, ok := o.layers[jm.ID]
+	if !ok {
+		layer = &pullLayer{}
+		o.layers[jm.ID] = layer
+	}
+
+	switch jm.Status {
+	case "Downloading":
+		if jm.Progress != nil {
+			layer.current = jm.Progress.Current
+			layer.total = jm.Progress.Total
+		}
+	case "Download complete":
+		layer.current = layer.total
+	case "Pull complete", "Already exists":
+		layer.current = layer.total
+		layer.completed = true
+	}
+
+	return true
+}
+
+// progress returns the aggregated progress of the layers
+func (o *pullOutput) progress(layer string, status string) PullProgress {
+	p := PullProgress{
+		Image:  o.image,
+		Layers: len(o.layers),
+		Layer:  layer,
+		Status: status,
+	}
+
+	for _, l := range o.layers {
+		p.Current += l.current
+		p.Total += l.total
+		if l.completed {
+			p.CompletedLayers++
+		}
+	}
+
+	return p
+}
@@ -0,0 +1,227 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pullProgressCollector struct {
+	progress []PullProgress
+}
+
+func (c *pullProgressCollector) Accept(p PullProgress) {
+	c.progress = append(c.progress, p)
+}
+
+type collectingLogger struct {
+	lines []string
+}
+
+func (l *collectingLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestPullPolicies(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	local := &types.ImageInspect{
+		Architecture: "amd64",
+		Os:           "linux",
+		Created:      now.Add(-72 * time.Hour).Format(time.RFC3339Nano),
+		RepoDigests:  []string{"nginx@sha256:aaaa"},
+		Metadata:     types.ImageMetadata{LastTagTime: now.Add(-time.Hour)},
+	}
+
+	remoteDigest := func(digest string) func(context.Context) (string, error) {
+		return func(context.Context) (string, error) {
+			return digest, nil
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		policy   PullPolicy
+		image    ImagePullInfo
+		expected bool
+	}{
+		{name: "always", policy: PullAlways(), image: ImagePullInfo{Local: local}, expected: true},
+		{name: "missing: image exists", policy: PullIfMissing(), image: ImagePullInfo{Local: local}, expected: false},
+		{name: "missing: image doesn't exist", policy: PullIfMissing(), image: ImagePullInfo{}, expected: true},
+		{
+			name:     "missing: image of another platform",
+			policy:   PullIfMissing(),
+			image:    ImagePullInfo{Local: local, Platform: &specs.Platform{OS: "linux", Architecture: "arm64"}},
+			expected: true,
+		},
+		{
+			name:     "older than: recently tagged",
+			policy:   pullIfOlderThan{maxAge: 24 * time.Hour, now: func() time.Time { return now }},
+			image:    ImagePullInfo{Local: local},
+			expected: false,
+		},
+		{
+			name:     "older than: tagged long ago",
+			policy:   pullIfOlderThan{maxAge: 30 * time.Minute, now: func() time.Time { return now }},
+			image:    ImagePullInfo{Local: local},
+			expected: true,
+		},
+		{
+			name:   "older than: never tagged, created long ago",
+			policy: pullIfOlderThan{maxAge: 24 * time.Hour, now: func() time.Time { return now }},
+			image: ImagePullInfo{Local: &types.ImageInspect{
+				Architecture: "amd64",
+				Os:           "linux",
+				Created:      local.Created,
+			}},
+			expected: true,
+		},
+		{name: "older than: image doesn't exist", policy: PullIfOlderThan(24 * time.Hour), image: ImagePullInfo{}, expected: true},
+		{
+			name:     "digest mismatch: same digest",
+			policy:   PullIfDigestMismatch(),
+			image:    ImagePullInfo{Local: local, remoteDigest: remoteDigest("sha256:aaaa")},
+			expected: false,
+		},
+		{
+			name:     "digest mismatch: tag pushed again",
+			policy:   PullIfDigestMismatch(),
+			image:    ImagePullInfo{Local: local, remoteDigest: remoteDigest("sha256:bbbb")},
+			expected: true,
+		},
+		{name: "digest mismatch: image doesn't exist", policy: PullIfDigestMismatch(), image: ImagePullInfo{}, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			shouldPull, err := tc.policy.ShouldPull(ctx, tc.image)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, shouldPull)
+		})
+	}
+
+	t.Run("digest mismatch: registry not reachable", func(t *testing.T) {
+		image := ImagePullInfo{
+			Image: "nginx",
+			Local: local,
+			remoteDigest: func(context.Context) (string, error) {
+				return "", errors.New("connection refused")
+			},
+		}
+
+		_, err := PullIfDigestMismatch().ShouldPull(ctx, image)
+		require.Error(t, err)
+		assert.Equal(t, "get digest of image nginx: connection refused", err.Error())
+	})
+}
+
+func TestPullOutput(t *testing.T) {
+	consumer := &pullProgressCollector{}
+
+	r := buildStream(t,
+		jsonmessage.JSONMessage{ID: "latest", Status: "Pulling from library/nginx"},
+		jsonmessage.JSONMessage{ID: "layer1", Status: "Already exists"},
+		jsonmessage.JSONMessage{ID: "layer2", Status: "Pulling fs layer"},
+		jsonmessage.JSONMessage{ID: "layer3", Status: "Pulling fs layer"},
+		jsonmessage.JSONMessage{ID: "layer2", Status: "Downloading", Progress: &jsonmessage.JSONProgress{Current: 100, Total: 1000}},
+		jsonmessage.JSONMessage{ID: "layer3", Status: "Downloading", Progress: &jsonmessage.JSONProgress{Current: 50, Total: 500}},
+		jsonmessage.JSONMessage{ID: "layer2", Status: "Download complete"},
+		jsonmessage.JSONMessage{ID: "layer2", Status: "Pull complete"},
+		jsonmessage.JSONMessage{ID: "layer3", Status: "Download complete"},
+		jsonmessage.JSONMessage{ID: "layer3", Status: "Pull complete"},
+		jsonmessage.JSONMessage{Status: "Digest: sha256:aaaa"},
+		jsonmessage.JSONMessage{Status: "Status: Downloaded newer image for nginx:latest"},
+	)
+
+	progress, err := newPullOutput("nginx:latest", consumer).read(r)
+	require.NoError(t, err)
+
+	assert.Equal(t, PullProgress{Image: "nginx:latest", Layers: 3, CompletedLayers: 3, Current: 1500, Total: 1500}, progress)
+
+	require.Len(t, consumer.progress, 9)
+	assert.Equal(t, PullProgress{
+		Image:           "nginx:latest",
+		Layers:          3,
+		CompletedLayers: 1,
+		Current:         150,
+		Total:           1500,
+		Layer:           "layer3",
+		Status:          "Downloading",
+	}, consumer.progress[4])
+}
+
+func TestPullOutput_error(t *testing.T) {
+	r := buildStream(t,
+		jsonmessage.JSONMessage{ID: "layer1", Status: "Pulling fs layer"},
+		jsonmessage.JSONMessage{Error: &jsonmessage.JSONError{Message: "unexpected EOF"}},
+	)
+
+	_, err := newPullOutput("nginx:latest", nil).read(r)
+	require.Error(t, err)
+	assert.Equal(t, "pull image nginx:latest: unexpected EOF", err.Error())
+}
+
+func TestPullProgressLogger(t *testing.T) {
+	logger := &collectingLogger{}
+	now := time.Now()
+
+	l := newPullProgressLogger(logger)
+	l.logged = now
+	l.now = func() time.Time { return now }
+
+	progress := PullProgress{Image: "nginx:latest", Layers: 2, CompletedLayers: 1, Current: 1000, Total: 2000}
+
+	l.Accept(progress)
+	assert.Empty(t, logger.lines)
+
+	now = now.Add(pullProgressLogInterval)
+	l.Accept(progress)
+	l.Accept(progress)
+	assert.Equal(t, []string{"⏳ Pulling image nginx:latest: 1/2 layers, 1kB/2kB"}, logger.lines)
+}
+
+func TestPullPolicy_progressConsumer(t *testing.T) {
+	ctx := context.Background()
+
+	// the image is not pulled by other tests, and removed so that the progress of its layers is reported
+	image := "docker.io/nginx:1.25.3-alpine"
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	_, err = provider.Client().ImageRemove(ctx, image, types.ImageRemoveOptions{Force: true})
+	if err != nil && !client.IsErrNotFound(err) {
+		require.NoError(t, err)
+	}
+
+	consumer := &pullProgressCollector{}
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		// pullPolicy {
+		ContainerRequest: ContainerRequest{
+			Image:                image,
+			PullPolicy:           PullIfOlderThan(24 * time.Hour),
+			PullProgressConsumer: consumer,
+		},
+		// }
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c.Terminate(ctx))
+	})
+
+	require.NotEmpty(t, consumer.progress)
+	last := consumer.progress[len(consumer.progress)-1]
+	assert.Equal(t, last.Layers, last.CompletedLayers)
+}
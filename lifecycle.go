@@ -9,6 +9,8 @@ import (
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/go-connections/nat"
 	"golang.org/x/exp/slices"
+
+	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 // ContainerRequestHook is a hook that will be called before a container is created.
@@ -31,18 +33,38 @@ type ContainerRequestHook func(ctx context.Context, req ContainerRequest) error
 // For that, it will receive a Container, modify it and return an error if needed.
 type ContainerHook func(ctx context.Context, container Container) error
 
+// ContainerReadinessHook is a hook that will be called after the wait strategy of a container
+// finishes, whether the container is ready or not. It receives the report of the wait strategy,
+// including its timing, number of attempts and outcome, using the lifecycle hook:
+// - Readied
+type ContainerReadinessHook func(ctx context.Context, container Container, report *wait.ReadinessReport) error
+
+// ContainerNetworkHook is a hook that will be called when a running container is connected to,
+// or disconnected from, a network, using the different lifecycle hooks that are available:
+// - NetworkConnecting
+// - NetworkConnected
+// - NetworkDisconnecting
+// - NetworkDisconnected
+// For that, it will receive the Container and the network, and return an error if needed.
+type ContainerNetworkHook func(ctx context.Context, container Container, network *DockerNetwork) error
+
 // ContainerLifecycleHooks is a struct that contains all the hooks that can be used
 // to modify the container lifecycle. All the container lifecycle hooks except the PreCreates hooks
 // will be passed to the container once it's created
 type ContainerLifecycleHooks struct {
-	PreCreates     []ContainerRequestHook
-	PostCreates    []ContainerHook
-	PreStarts      []ContainerHook
-	PostStarts     []ContainerHook
-	PreStops       []ContainerHook
-	PostStops      []ContainerHook
-	PreTerminates  []ContainerHook
-	PostTerminates []ContainerHook
+	PreCreates             []ContainerRequestHook
+	PostCreates            []ContainerHook
+	PreStarts              []ContainerHook
+	PostStarts             []ContainerHook
+	PostReadies            []ContainerReadinessHook
+	PreNetworkConnects     []ContainerNetworkHook
+	PostNetworkConnects    []ContainerNetworkHook
+	PreNetworkDisconnects  []ContainerNetworkHook
+	PostNetworkDisconnects []ContainerNetworkHook
+	PreStops               []ContainerHook
+	PostStops              []ContainerHook
+	PreTerminates          []ContainerHook
+	PostTerminates         []ContainerHook
 }
 
 var DefaultLoggingHook = func(logger Logging) ContainerLifecycleHooks {
@@ -75,6 +97,36 @@ var DefaultLoggingHook = func(logger Logging) ContainerLifecycleHooks {
 				return nil
 			},
 		},
+		PostReadies: []ContainerReadinessHook{
+			func(ctx context.Context, c Container, report *wait.ReadinessReport) error {
+				logger.Printf("🔔 Container %s: %s", shortContainerID(c), report)
+				return nil
+			},
+		},
+		PreNetworkConnects: []ContainerNetworkHook{
+			func(ctx context.Context, c Container, nw *DockerNetwork) error {
+				logger.Printf("🐳 Connecting container %s to network %s", shortContainerID(c), nw.Name)
+				return nil
+			},
+		},
+		PostNetworkConnects: []ContainerNetworkHook{
+			func(ctx context.Context, c Container, nw *DockerNetwork) error {
+				logger.Printf("🔗 Container %s connected to network %s", shortContainerID(c), nw.Name)
+				return nil
+			},
+		},
+		PreNetworkDisconnects: []ContainerNetworkHook{
+			func(ctx context.Context, c Container, nw *DockerNetwork) error {
+				logger.Printf("🐳 Disconnecting container %s from network %s", shortContainerID(c), nw.Name)
+				return nil
+			},
+		},
+		PostNetworkDisconnects: []ContainerNetworkHook{
+			func(ctx context.Context, c Container, nw *DockerNetwork) error {
+				logger.Printf("✂️ Container %s disconnected from network %s", shortContainerID(c), nw.Name)
+				return nil
+			},
+		},
 		PreStops: []ContainerHook{
 			func(ctx context.Context, c Container) error {
 				logger.Printf("🐳 Stopping container: %s", shortContainerID(c))
@@ -102,6 +154,149 @@ var DefaultLoggingHook = func(logger Logging) ContainerLifecycleHooks {
 	}
 }
 
+// NetworkRequestHook is a hook that will be called before a network is created.
+// It receives the NetworkRequest, and returns an error if needed, using the lifecycle hook:
+// - Creating
+type NetworkRequestHook func(ctx context.Context, req NetworkRequest) error
+
+// NetworkHook is a hook that will be called after a network is created, and before and after
+// it is removed, using the different lifecycle hooks that are available:
+// - Created
+// - Removing
+// - Removed
+// For that, it will receive the network, and return an error if needed.
+type NetworkHook func(ctx context.Context, network *DockerNetwork) error
+
+// NetworkLifecycleHooks is a struct that contains all the hooks that can be used
+// to follow the network lifecycle. All the network lifecycle hooks except the PreCreates hooks
+// will be passed to the network once it's created, or reused
+type NetworkLifecycleHooks struct {
+	PreCreates  []NetworkRequestHook
+	PostCreates []NetworkHook
+	PreRemoves  []NetworkHook
+	PostRemoves []NetworkHook
+}
+
+// DefaultNetworkLoggingHook is a hook that logs the lifecycle of a network
+var DefaultNetworkLoggingHook = func(logger Logging) NetworkLifecycleHooks {
+	return NetworkLifecycleHooks{
+		PreCreates: []NetworkRequestHook{
+			func(ctx context.Context, req NetworkRequest) error {
+				logger.Printf("🐳 Creating network %s", req.Name)
+				return nil
+			},
+		},
+		PostCreates: []NetworkHook{
+			func(ctx context.Context, n *DockerNetwork) error {
+				logger.Printf("✅ Network created: %s", n.Name)
+				return nil
+			},
+		},
+		PreRemoves: []NetworkHook{
+			func(ctx context.Context, n *DockerNetwork) error {
+				logger.Printf("🐳 Removing network: %s", n.Name)
+				return nil
+			},
+		},
+		PostRemoves: []NetworkHook{
+			func(ctx context.Context, n *DockerNetwork) error {
+				logger.Printf("🚫 Network removed: %s", n.Name)
+				return nil
+			},
+		},
+	}
+}
+
+// creatingHook is a hook that will be called before a network is created.
+func (req NetworkRequest) creatingHook(ctx context.Context) error {
+	for _, lifecycleHooks := range req.LifecycleHooks {
+		err := lifecycleHooks.Creating(ctx)(req)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createdHook is a hook that will be called after a network is created
+func (n *DockerNetwork) createdHook(ctx context.Context) error {
+	for _, lifecycleHooks := range n.lifecycleHooks {
+		err := lifecycleHooks.Created(ctx)(n)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removingHook is a hook that will be called before a network is removed
+func (n *DockerNetwork) removingHook(ctx context.Context) error {
+	for _, lifecycleHooks := range n.lifecycleHooks {
+		err := lifecycleHooks.Removing(ctx)(n)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removedHook is a hook that will be called after a network is removed
+func (n *DockerNetwork) removedHook(ctx context.Context) error {
+	for _, lifecycleHooks := range n.lifecycleHooks {
+		err := lifecycleHooks.Removed(ctx)(n)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Creating is a hook that will be called before a network is created.
+func (c NetworkLifecycleHooks) Creating(ctx context.Context) func(req NetworkRequest) error {
+	return func(req NetworkRequest) error {
+		for _, hook := range c.PreCreates {
+			if err := hook(ctx, req); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// networkHookFn is a helper function that will create a function to be returned by the network
+// lifecycle hooks. The created function will iterate over all the hooks and call them one by one.
+func networkHookFn(ctx context.Context, networkHook []NetworkHook) func(network *DockerNetwork) error {
+	return func(network *DockerNetwork) error {
+		for _, hook := range networkHook {
+			if err := hook(ctx, network); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// Created is a hook that will be called after a network is created
+func (c NetworkLifecycleHooks) Created(ctx context.Context) func(network *DockerNetwork) error {
+	return networkHookFn(ctx, c.PostCreates)
+}
+
+// Removing is a hook that will be called before a network is removed
+func (c NetworkLifecycleHooks) Removing(ctx context.Context) func(network *DockerNetwork) error {
+	return networkHookFn(ctx, c.PreRemoves)
+}
+
+// Removed is a hook that will be called after a network is removed
+func (c NetworkLifecycleHooks) Removed(ctx context.Context) func(network *DockerNetwork) error {
+	return networkHookFn(ctx, c.PostRemoves)
+}
+
 // creatingHook is a hook that will be called before a container is created.
 func (req ContainerRequest) creatingHook(ctx context.Context) error {
 	for _, lifecycleHooks := range req.LifecycleHooks {
@@ -152,6 +347,66 @@ func (c *DockerContainer) startedHook(ctx context.Context) error {
 	return nil
 }
 
+// readiedHook is a hook that will be called after the wait strategy of a container finishes
+func (c *DockerContainer) readiedHook(ctx context.Context, report *wait.ReadinessReport) error {
+	for _, lifecycleHooks := range c.lifecycleHooks {
+		err := lifecycleHooks.Readied(ctx)(c, report)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// networkConnectingHook is a hook that will be called before a container is connected to a network
+func (c *DockerContainer) networkConnectingHook(ctx context.Context, nw *DockerNetwork) error {
+	for _, lifecycleHooks := range c.lifecycleHooks {
+		err := lifecycleHooks.NetworkConnecting(ctx)(c, nw)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// networkConnectedHook is a hook that will be called after a container is connected to a network
+func (c *DockerContainer) networkConnectedHook(ctx context.Context, nw *DockerNetwork) error {
+	for _, lifecycleHooks := range c.lifecycleHooks {
+		err := lifecycleHooks.NetworkConnected(ctx)(c, nw)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// networkDisconnectingHook is a hook that will be called before a container is disconnected from a network
+func (c *DockerContainer) networkDisconnectingHook(ctx context.Context, nw *DockerNetwork) error {
+	for _, lifecycleHooks := range c.lifecycleHooks {
+		err := lifecycleHooks.NetworkDisconnecting(ctx)(c, nw)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// networkDisconnectedHook is a hook that will be called after a container is disconnected from a network
+func (c *DockerContainer) networkDisconnectedHook(ctx context.Context, nw *DockerNetwork) error {
+	for _, lifecycleHooks := range c.lifecycleHooks {
+		err := lifecycleHooks.NetworkDisconnected(ctx)(c, nw)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // printLogs is a helper function that will print the logs of a Docker container
 // We are going to use this helper function to inform the user of the logs when an error occurs
 func (c *DockerContainer) printLogs(ctx context.Context, cause error) {
@@ -260,6 +515,53 @@ func (c ContainerLifecycleHooks) Started(ctx context.Context) func(container Con
 	return containerHookFn(ctx, c.PostStarts)
 }
 
+// Readied is a hook that will be called after the wait strategy of a container finishes
+func (c ContainerLifecycleHooks) Readied(ctx context.Context) func(container Container, report *wait.ReadinessReport) error {
+	return func(container Container, report *wait.ReadinessReport) error {
+		for _, hook := range c.PostReadies {
+			if err := hook(ctx, container, report); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// containerNetworkHookFn is a helper function that will create a function to be returned by the
+// network lifecycle hooks. The created function will iterate over all the hooks and call them one by one.
+func containerNetworkHookFn(ctx context.Context, networkHook []ContainerNetworkHook) func(container Container, nw *DockerNetwork) error {
+	return func(container Container, nw *DockerNetwork) error {
+		for _, hook := range networkHook {
+			if err := hook(ctx, container, nw); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// NetworkConnecting is a hook that will be called before a container is connected to a network
+func (c ContainerLifecycleHooks) NetworkConnecting(ctx context.Context) func(container Container, nw *DockerNetwork) error {
+	return containerNetworkHookFn(ctx, c.PreNetworkConnects)
+}
+
+// NetworkConnected is a hook that will be called after a container is connected to a network
+func (c ContainerLifecycleHooks) NetworkConnected(ctx context.Context) func(container Container, nw *DockerNetwork) error {
+	return containerNetworkHookFn(ctx, c.PostNetworkConnects)
+}
+
+// NetworkDisconnecting is a hook that will be called before a container is disconnected from a network
+func (c ContainerLifecycleHooks) NetworkDisconnecting(ctx context.Context) func(container Container, nw *DockerNetwork) error {
+	return containerNetworkHookFn(ctx, c.PreNetworkDisconnects)
+}
+
+// NetworkDisconnected is a hook that will be called after a container is disconnected from a network
+func (c ContainerLifecycleHooks) NetworkDisconnected(ctx context.Context) func(container Container, nw *DockerNetwork) error {
+	return containerNetworkHookFn(ctx, c.PostNetworkDisconnects)
+}
+
 // Stopping is a hook that will be called before a container is stopped
 func (c ContainerLifecycleHooks) Stopping(ctx context.Context) func(container Container) error {
 	return containerHookFn(ctx, c.PreStops)
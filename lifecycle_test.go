@@ -636,6 +636,42 @@ func TestLifecycleHooks_WithMultipleHooks(t *testing.T) {
 	require.Equal(t, 20, len(dl.data))
 }
 
+func TestLifecycleHooks_PostReadies(t *testing.T) {
+	ctx := context.Background()
+
+	// reqWithReadinessHook {
+	var reports []*wait.ReadinessReport
+
+	req := ContainerRequest{
+		Image:        nginxAlpineImage,
+		ExposedPorts: []string{"80/tcp"},
+		WaitingFor:   wait.ForHTTP("/").WithPort("80/tcp"),
+		LifecycleHooks: []ContainerLifecycleHooks{
+			{
+				PostReadies: []ContainerReadinessHook{
+					func(ctx context.Context, c Container, report *wait.ReadinessReport) error {
+						reports = append(reports, report)
+						return nil
+					},
+				},
+			},
+		},
+	}
+	// }
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	require.Nil(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	require.Len(t, reports, 1)
+	assert.True(t, reports[0].Ready())
+	assert.GreaterOrEqual(t, reports[0].Attempts, 1)
+	assert.Equal(t, "http GET / 200", reports[0].Detail)
+}
+
 type linesTestLogger struct {
 	data []string
 }
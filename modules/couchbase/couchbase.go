@@ -378,15 +378,7 @@ func (c *CouchbaseContainer) waitUntilAllNodesAreHealthy(ctx context.Context) er
 		WithStatusCodeMatcher(func(status int) bool {
 			return status == http.StatusOK
 		}).
-		WithResponseMatcher(func(body io.Reader) bool {
-			response, err := io.ReadAll(body)
-			if err != nil {
-				return false
-			}
-			status := gjson.Get(string(response), "nodes.0.status")
-
-			return status.String() == "healthy"
-		}))
+		WithJSONMatcher(`$.nodes[0].status == "healthy"`))
 
 	if contains(c.config.enabledServices, query) {
 		waitStrategy = append(waitStrategy, wait.ForHTTP("/admin/ping").
@@ -132,20 +132,19 @@ func configureCertificate(settings *Options, req *testcontainers.GenericContaine
 			func(ctx context.Context, container testcontainers.Container) error {
 				const defaultCaCertPath = "/usr/share/elasticsearch/config/certs/http_ca.crt"
 
-				readCloser, err := container.CopyFileFromContainer(ctx, defaultCaCertPath)
-				if err != nil {
-					return err
-				}
-
-				// receive the bytes from the default location
-				certBytes, err := io.ReadAll(readCloser)
-				if err != nil {
-					return err
-				}
-
-				settings.CACert = certBytes
-
-				return nil
+				// receive the bytes from the default location, once the certificate has been written
+				return wait.ForFile(defaultCaCertPath).
+					WithMatcher(func(r io.Reader) error {
+						certBytes, err := io.ReadAll(r)
+						if err != nil {
+							return err
+						}
+
+						settings.CACert = certBytes
+
+						return nil
+					}).
+					WaitUntilReady(ctx, container)
 			})
 	}
 
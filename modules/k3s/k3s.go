@@ -59,7 +59,10 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		Env: map[string]string{
 			"K3S_KUBECONFIG_MODE": "644",
 		},
-		WaitingFor: wait.ForLog(".*Node controller sync successful.*").AsRegexp(),
+		WaitingFor: wait.ForAll(
+			wait.ForLog(".*Node controller sync successful.*").AsRegexp(),
+			wait.ForFile(defaultKubeConfigK3sPath),
+		),
 	}
 
 	genericContainerReq := testcontainers.GenericContainerRequest{
@@ -80,12 +80,14 @@ func WithCache() testcontainers.CustomizeRequestOption {
 	}
 }
 
-// RunContainer creates an instance of the K6 container type
+// RunContainer creates an instance of the K6 container type. If the test run fails, the container
+// is returned along with the *wait.ExitError, so it can be terminated.
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*K6Container, error) {
 	req := testcontainers.ContainerRequest{
-		Image:      "szkiba/k6x:v0.3.1",
-		Cmd:        []string{"run"},
-		WaitingFor: wait.ForExit(),
+		Image: "szkiba/k6x:v0.3.1",
+		Cmd:   []string{"run"},
+		// a failing test run makes the container exit with a non-zero code
+		WaitingFor: wait.ForExit().WithExitCode(0),
 	}
 
 	genericContainerReq := testcontainers.GenericContainerRequest{
@@ -99,7 +101,11 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
-		return nil, err
+		if container == nil {
+			return nil, err
+		}
+		// the container of a failed test run is returned too, so it can be terminated
+		return &K6Container{Container: container}, err
 	}
 
 	return &K6Container{Container: container}, nil
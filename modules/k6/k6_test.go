@@ -2,8 +2,17 @@ package k6
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 func TestK6(t *testing.T) {
@@ -35,15 +44,28 @@ func TestK6(t *testing.T) {
 			}
 
 			container, err := RunContainer(ctx, WithCache(), WithTestScript(absPath))
+			if container != nil {
+				// Clean up the container after the test is complete, including a failed test run
+				t.Cleanup(func() {
+					if err := container.Terminate(ctx); err != nil {
+						t.Fatalf("failed to terminate container: %s", err)
+					}
+				})
+			}
+			if tc.expect != 0 {
+				// a failing test run makes the container fail to start
+				var exitErr *wait.ExitError
+				if !errors.As(err, &exitErr) {
+					t.Fatalf("expected exit error, got %v", err)
+				}
+				if exitErr.ExitCode != tc.expect {
+					t.Fatalf("expected %d got %d", tc.expect, exitErr.ExitCode)
+				}
+				return
+			}
 			if err != nil {
 				t.Fatal(err)
 			}
-			// Clean up the container after the test is complete
-			t.Cleanup(func() {
-				if err := container.Terminate(ctx); err != nil {
-					t.Fatalf("failed to terminate container: %s", err)
-				}
-			})
 
 			// assert the result of the test
 			state, err := container.State(ctx)
@@ -56,3 +78,50 @@ func TestK6(t *testing.T) {
 		})
 	}
 }
+
+func TestK6HostAccess(t *testing.T) {
+	ctx := context.Background()
+
+	// the handler under test runs on the host
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	port := server.Listener.Addr().(*net.TCPAddr).Port
+
+	absPath, err := filepath.Abs(filepath.Join("scripts", "host.js"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// exposeHostPorts {
+	container, err := RunContainer(
+		ctx,
+		WithTestScript(absPath),
+		testcontainers.ExposeHostPorts(port),
+		SetEnvVar("HOST_URL", fmt.Sprintf("http://%s:%d", testcontainers.HostInternal, port)),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	state, err := container.State(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.ExitCode != 0 {
+		t.Fatalf("expected 0 got %d", state.ExitCode)
+	}
+	if atomic.LoadInt32(&requests) == 0 {
+		t.Fatal("expected the handler to receive requests")
+	}
+}
@@ -0,0 +1,16 @@
+import { check } from 'k6';
+import http from 'k6/http';
+
+export const options = {
+  thresholds: {
+    checks: ['rate==1'],
+  },
+};
+
+export default function () {
+  const res = http.get(__ENV.HOST_URL);
+
+  check(res, {
+    'is status 200': (r) => r.status === 200,
+  });
+}
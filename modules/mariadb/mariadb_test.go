@@ -196,6 +196,27 @@ func TestMariaDBWithScripts(t *testing.T) {
 	assertDataCanBeFetched(t, ctx, container)
 }
 
+func TestMariaDBWithWaitForSchema(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := RunContainer(ctx,
+		WithScripts(filepath.Join("testdata", "schema.sql")),
+		WithWaitForSchema(defaultDatabaseName, "profile"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	assertDataCanBeFetched(t, ctx, container)
+}
+
 func assertDataCanBeFetched(t *testing.T, ctx context.Context, container *MariaDBContainer) {
 	connectionString, err := container.ConnectionString(ctx)
 	if err != nil {
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/docker/go-connections/nat"
+
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
@@ -38,6 +40,19 @@ func WithPassword(password string) testcontainers.CustomizeRequestOption {
 	}
 }
 
+// WithWaitForSchema waits until the given schema and its tables exist in the default database of the user, e.g. once
+// the database is initialised, see testcontainers.WithAdditionalWaitStrategy. The caller must import the go-mssqldb driver.
+func WithWaitForSchema(schema string, tables ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		// the URL is built when waiting, so it uses the final credentials of the request
+		dbURL := func(host string, port nat.Port) string {
+			return fmt.Sprintf("sqlserver://%s:%s@%s:%s", defaultUsername, req.Env["MSSQL_SA_PASSWORD"], host, port.Port())
+		}
+
+		testcontainers.WithAdditionalWaitStrategy(wait.ForSQL(defaultPort, "sqlserver", dbURL).WithSchema(schema, tables...))(req)
+	}
+}
+
 // RunContainer creates an instance of the MSSQLServer container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MSSQLServerContainer, error) {
 	req := testcontainers.ContainerRequest{
@@ -3,6 +3,7 @@ package mssql
 import (
 	"context"
 	"database/sql"
+	"path/filepath"
 	"testing"
 
 	_ "github.com/microsoft/go-mssqldb"
@@ -54,6 +55,55 @@ func TestMSSQLServer(t *testing.T) {
 	}
 }
 
+func TestMSSQLServerWithWaitForSchema(t *testing.T) {
+	ctx := context.Background()
+
+	// the seed script creates the app schema and its users table once the server is started
+	seed := testcontainers.CustomizeRequestOption(func(req *testcontainers.GenericContainerRequest) {
+		req.Files = append(req.Files,
+			testcontainers.ContainerFile{HostFilePath: filepath.Join("testdata", "seed.sql"), ContainerFilePath: "/tmp/seed.sql", FileMode: 0o644},
+			testcontainers.ContainerFile{HostFilePath: filepath.Join("testdata", "seed-entrypoint.sh"), ContainerFilePath: "/tmp/seed-entrypoint.sh", FileMode: 0o755},
+		)
+		req.Entrypoint = []string{"/tmp/seed-entrypoint.sh"}
+	})
+
+	container, err := RunContainer(ctx,
+		WithAcceptEULA(),
+		seed,
+		WithWaitForSchema("app", "users"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	connectionString, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlserver", connectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// the table created by the seed script exists as soon as the container is started
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM app.users").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 user, got %d", count)
+	}
+}
+
 func TestMSSQLServerWithMissingEulaOption(t *testing.T) {
 	ctx := context.Background()
 
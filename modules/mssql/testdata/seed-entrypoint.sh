@@ -0,0 +1,11 @@
+#!/bin/bash
+# starts SQL Server and runs the seed script once it accepts connections,
+# as the image doesn't run init scripts
+/opt/mssql/bin/sqlservr &
+
+sqlcmd=$(ls /opt/mssql-tools*/bin/sqlcmd | head -n 1)
+until "$sqlcmd" -C -S localhost -U sa -P "$MSSQL_SA_PASSWORD" -i /tmp/seed.sql; do
+    sleep 1
+done
+
+wait
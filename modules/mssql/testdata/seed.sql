@@ -0,0 +1,11 @@
+CREATE SCHEMA app;
+GO
+
+CREATE TABLE app.users (
+    id INT PRIMARY KEY,
+    name NVARCHAR(128) NOT NULL
+);
+GO
+
+INSERT INTO app.users (id, name) VALUES (1, 'testcontainers');
+GO
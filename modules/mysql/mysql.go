@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/docker/go-connections/nat"
+
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
@@ -46,6 +48,23 @@ func WithDefaultCredentials() testcontainers.CustomizeRequestOption {
 	}
 }
 
+// WithWaitForSchema waits until the given schema, which is a database in MySQL, and its tables exist, e.g. once
+// the init scripts are run, see testcontainers.WithAdditionalWaitStrategy. The caller must import the go-sql-driver/mysql driver.
+func WithWaitForSchema(schema string, tables ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		// the URL is built when waiting, so it uses the final credentials of the request
+		dbURL := func(host string, port nat.Port) string {
+			username, ok := req.Env["MYSQL_USER"]
+			if !ok {
+				username = rootUser
+			}
+			return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", username, req.Env["MYSQL_PASSWORD"], host, port.Port(), req.Env["MYSQL_DATABASE"])
+		}
+
+		testcontainers.WithAdditionalWaitStrategy(wait.ForSQL("3306/tcp", "mysql", dbURL).WithSchema(schema, tables...))(req)
+	}
+}
+
 // RunContainer creates an instance of the MySQL container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MySQLContainer, error) {
 	req := testcontainers.ContainerRequest{
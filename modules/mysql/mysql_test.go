@@ -193,3 +193,38 @@ func TestMySQLWithScripts(t *testing.T) {
 		t.Fatal("The expected record was not found in the database.")
 	}
 }
+
+func TestMySQLWithWaitForSchema(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := RunContainer(ctx,
+		WithScripts(filepath.Join("testdata", "schema.sql")),
+		WithWaitForSchema(defaultDatabaseName, "profile"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	connectionString, _ := container.ConnectionString(ctx)
+
+	db, err := sql.Open("mysql", connectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var name string
+	if err := db.QueryRow("SELECT name from profile").Scan(&name); err != nil {
+		t.Fatal(err)
+	}
+	if name != "profile 1" {
+		t.Fatal("The expected record was not found in the database.")
+	}
+}
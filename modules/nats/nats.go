@@ -27,7 +27,8 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		Image:        "nats:2.9",
 		ExposedPorts: []string{defaultClientPort, defaultRoutingPort, defaultMonitoringPort},
 		Cmd:          []string{"-DV", "-js"},
-		WaitingFor:   wait.ForLog("Listening for client connections on 0.0.0.0:4222"),
+		// the server greets every client connection with its INFO
+		WaitingFor: wait.ForTCP(defaultClientPort).Expect(wait.HasPrefixBytes([]byte("INFO "))),
 	}
 
 	genericContainerReq := testcontainers.GenericContainerRequest{
@@ -7,7 +7,10 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/docker/go-connections/nat"
+
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 const (
@@ -108,6 +111,19 @@ func WithUsername(user string) testcontainers.CustomizeRequestOption {
 	}
 }
 
+// WithWaitForSchema waits until the given schema and its tables exist in the database, e.g. once the init
+// scripts are run, see testcontainers.WithAdditionalWaitStrategy. The caller must import the lib/pq driver.
+func WithWaitForSchema(schema string, tables ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		// the URL is built when waiting, so it uses the final credentials of the request
+		dbURL := func(host string, port nat.Port) string {
+			return fmt.Sprintf("postgres://%s:%s@%s/%s?sslmode=disable", req.Env["POSTGRES_USER"], req.Env["POSTGRES_PASSWORD"], net.JoinHostPort(host, port.Port()), req.Env["POSTGRES_DB"])
+		}
+
+		testcontainers.WithAdditionalWaitStrategy(wait.ForSQL("5432/tcp", "postgres", dbURL).WithSchema(schema, tables...))(req)
+	}
+}
+
 // RunContainer creates an instance of the postgres container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*PostgresContainer, error) {
 	req := testcontainers.ContainerRequest{
@@ -208,3 +208,40 @@ func TestWithInitScript(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 }
+
+func TestWithWaitForSchema(t *testing.T) {
+	ctx := context.Background()
+
+	// waitForSchema {
+	container, err := RunContainer(ctx,
+		testcontainers.WithImage("docker.io/postgres:15.2-alpine"),
+		WithInitScripts(filepath.Join("testdata", "init-user-db.sh")),
+		WithDatabase(dbname),
+		WithUsername(user),
+		WithPassword(password),
+		// table created in init script. See testdata/init-user-db.sh
+		WithWaitForSchema("public", "testdb"),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	assert.NoError(t, err)
+
+	db, err := sql.Open("postgres", connStr)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	var name string
+	err = db.QueryRow("SELECT name FROM testdb WHERE id = 1").Scan(&name)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", name)
+}
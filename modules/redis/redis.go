@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
@@ -51,7 +52,9 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 	req := testcontainers.ContainerRequest{
 		Image:        defaultImage,
 		ExposedPorts: []string{"6379/tcp"},
-		WaitingFor:   wait.ForLog("* Ready to accept connections"),
+		WaitingFor: wait.ForTCP("6379/tcp").
+			Send([]byte("PING\r\n")).
+			Expect(isPingReply),
 	}
 
 	genericContainerReq := testcontainers.GenericContainerRequest{
@@ -71,6 +74,12 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 	return &RedisContainer{Container: container}, nil
 }
 
+// isPingReply checks that the server is accepting commands: it replies to PING with PONG,
+// or with NOAUTH if a password has been set in the config file
+func isPingReply(response []byte) bool {
+	return bytes.HasPrefix(response, []byte("+PONG")) || bytes.HasPrefix(response, []byte("-NOAUTH"))
+}
+
 // WithConfigFile sets the config file to be used for the redis container, and sets the command to run the redis server
 // using the passed config file
 func WithConfigFile(configFile string) testcontainers.CustomizeRequestOption {
@@ -0,0 +1,182 @@
+package toxiproxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client is a client of the HTTP API of Toxiproxy, managing its proxies and their toxics
+type Client struct {
+	uri        string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the HTTP API at the given URI, e.g. http://localhost:8474
+func NewClient(uri string) *Client {
+	return &Client{
+		uri:        strings.TrimSuffix(uri, "/"),
+		httpClient: http.DefaultClient,
+	}
+}
+
+// Proxy is a TCP proxy of Toxiproxy, listening on an address of the Toxiproxy container
+// and forwarding the connections to the upstream address
+type Proxy struct {
+	Name     string `json:"name"`
+	Listen   string `json:"listen"`
+	Upstream string `json:"upstream"`
+	Enabled  bool   `json:"enabled"`
+
+	client *Client
+}
+
+// APIError is returned when the HTTP API of Toxiproxy returns an error
+type APIError struct {
+	StatusCode int
+	Message    string `json:"error"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("toxiproxy: %d: %s", e.StatusCode, e.Message)
+}
+
+// CreateProxy creates a proxy listening on the listen address of the Toxiproxy container,
+// e.g. "0.0.0.0:8666", and forwarding the connections to the upstream address
+func (c *Client) CreateProxy(ctx context.Context, name string, listen string, upstream string) (*Proxy, error) {
+	proxy := &Proxy{client: c}
+	req := Proxy{Name: name, Listen: listen, Upstream: upstream, Enabled: true}
+	if err := c.do(ctx, http.MethodPost, "/proxies", req, proxy); err != nil {
+		return nil, err
+	}
+	return proxy, nil
+}
+
+// Proxy returns the proxy with the given name
+func (c *Client) Proxy(ctx context.Context, name string) (*Proxy, error) {
+	proxy := &Proxy{client: c}
+	if err := c.do(ctx, http.MethodGet, "/proxies/"+url.PathEscape(name), nil, proxy); err != nil {
+		return nil, err
+	}
+	return proxy, nil
+}
+
+// Proxies returns all the proxies, by name
+func (c *Client) Proxies(ctx context.Context) (map[string]*Proxy, error) {
+	proxies := make(map[string]*Proxy)
+	if err := c.do(ctx, http.MethodGet, "/proxies", nil, &proxies); err != nil {
+		return nil, err
+	}
+	for _, proxy := range proxies {
+		proxy.client = c
+	}
+	return proxies, nil
+}
+
+// ResetState enables all the proxies and removes all their toxics
+func (c *Client) ResetState(ctx context.Context) error {
+	return c.do(ctx, http.MethodPost, "/reset", nil, nil)
+}
+
+// Enable enables the proxy, accepting connections again
+func (p *Proxy) Enable(ctx context.Context) error {
+	return p.setEnabled(ctx, true)
+}
+
+// Disable disables the proxy, closing all the connections and refusing new ones,
+// which simulates a service being down
+func (p *Proxy) Disable(ctx context.Context) error {
+	return p.setEnabled(ctx, false)
+}
+
+func (p *Proxy) setEnabled(ctx context.Context, enabled bool) error {
+	body := map[string]bool{"enabled": enabled}
+	return p.client.do(ctx, http.MethodPost, p.path(), body, p)
+}
+
+// Delete deletes the proxy
+func (p *Proxy) Delete(ctx context.Context) error {
+	return p.client.do(ctx, http.MethodDelete, p.path(), nil, nil)
+}
+
+// AddToxic adds the toxic to the proxy, returning its name, which is generated from its type
+// and stream if not set, e.g. latency_downstream
+func (p *Proxy) AddToxic(ctx context.Context, toxic Toxic) (string, error) {
+	var created toxicSpec
+	if err := p.client.do(ctx, http.MethodPost, p.path()+"/toxics", toxic.spec(), &created); err != nil {
+		return "", err
+	}
+	return created.Name, nil
+}
+
+// RemoveToxic removes the toxic with the given name from the proxy
+func (p *Proxy) RemoveToxic(ctx context.Context, name string) error {
+	return p.client.do(ctx, http.MethodDelete, p.path()+"/toxics/"+url.PathEscape(name), nil, nil)
+}
+
+// Toxics returns the names of the toxics of the proxy
+func (p *Proxy) Toxics(ctx context.Context) ([]string, error) {
+	var toxics []toxicSpec
+	if err := p.client.do(ctx, http.MethodGet, p.path()+"/toxics", nil, &toxics); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(toxics))
+	for _, t := range toxics {
+		names = append(names, t.Name)
+	}
+	return names, nil
+}
+
+func (p *Proxy) path() string {
+	return "/proxies/" + url.PathEscape(p.Name)
+}
+
+// do sends a request to the API, encoding the body and decoding the response into out, if not nil
+func (c *Client) do(ctx context.Context, method string, path string, body interface{}, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.uri+path, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if err := json.Unmarshal(respBody, apiErr); err != nil || apiErr.Message == "" {
+			apiErr.Message = strings.TrimSpace(string(respBody))
+		}
+		return apiErr
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBody, out)
+}
@@ -0,0 +1,147 @@
+package toxiproxy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// apiRequest is a request received by the fake API
+type apiRequest struct {
+	method string
+	path   string
+	body   map[string]interface{}
+}
+
+// fakeAPI records the requests and answers with the given responses, by method and path
+func fakeAPI(t *testing.T, responses map[string]string) (*Client, *[]apiRequest) {
+	var requests []apiRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := apiRequest{method: r.Method, path: r.URL.Path}
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if len(b) > 0 {
+			require.NoError(t, json.Unmarshal(b, &req.body))
+		}
+		requests = append(requests, req)
+
+		response, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"proxy not found","status":404}`))
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	return NewClient(server.URL), &requests
+}
+
+func TestClientCreateProxy(t *testing.T) {
+	client, requests := fakeAPI(t, map[string]string{
+		"POST /proxies": `{"name":"redis","listen":"[::]:8666","upstream":"redis:6379","enabled":true,"toxics":[]}`,
+	})
+
+	proxy, err := client.CreateProxy(context.Background(), "redis", "0.0.0.0:8666", "redis:6379")
+	require.NoError(t, err)
+
+	assert.Equal(t, "redis", proxy.Name)
+	assert.Equal(t, "[::]:8666", proxy.Listen)
+	assert.Equal(t, "redis:6379", proxy.Upstream)
+	assert.True(t, proxy.Enabled)
+
+	require.Len(t, *requests, 1)
+	assert.Equal(t, map[string]interface{}{
+		"name":     "redis",
+		"listen":   "0.0.0.0:8666",
+		"upstream": "redis:6379",
+		"enabled":  true,
+	}, (*requests)[0].body)
+}
+
+func TestClientError(t *testing.T) {
+	client, _ := fakeAPI(t, nil)
+
+	_, err := client.Proxy(context.Background(), "redis")
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.EqualError(t, err, "toxiproxy: 404: proxy not found")
+}
+
+func TestProxyToxics(t *testing.T) {
+	client, requests := fakeAPI(t, map[string]string{
+		"POST /proxies/redis/toxics":                      `{"name":"latency_downstream"}`,
+		"DELETE /proxies/redis/toxics/latency_downstream": ``,
+		"POST /proxies/redis":                             `{"name":"redis","listen":"[::]:8666","upstream":"redis:6379","enabled":false}`,
+	})
+	proxy := &Proxy{Name: "redis", client: client}
+
+	tests := []struct {
+		toxic    Toxic
+		expected map[string]interface{}
+	}{
+		{
+			toxic: Latency{Latency: time.Second, Jitter: 100 * time.Millisecond},
+			expected: map[string]interface{}{
+				"type": "latency", "stream": "downstream", "toxicity": 1.0,
+				"attributes": map[string]interface{}{"latency": 1000.0, "jitter": 100.0},
+			},
+		},
+		{
+			toxic: Bandwidth{ToxicOptions: ToxicOptions{Name: "slow", Stream: Upstream, Toxicity: 0.5}, Rate: 64},
+			expected: map[string]interface{}{
+				"name": "slow", "type": "bandwidth", "stream": "upstream", "toxicity": 0.5,
+				"attributes": map[string]interface{}{"rate": 64.0},
+			},
+		},
+		{
+			toxic: Timeout{Timeout: 2 * time.Second},
+			expected: map[string]interface{}{
+				"type": "timeout", "stream": "downstream", "toxicity": 1.0,
+				"attributes": map[string]interface{}{"timeout": 2000.0},
+			},
+		},
+		{
+			toxic: Slicer{AverageSize: 10, SizeVariation: 5, Delay: time.Millisecond},
+			expected: map[string]interface{}{
+				"type": "slicer", "stream": "downstream", "toxicity": 1.0,
+				"attributes": map[string]interface{}{"average_size": 10.0, "size_variation": 5.0, "delay": 1000.0},
+			},
+		},
+		{
+			toxic: ResetPeer{},
+			expected: map[string]interface{}{
+				"type": "reset_peer", "stream": "downstream", "toxicity": 1.0,
+				"attributes": map[string]interface{}{"timeout": 0.0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		*requests = nil
+
+		name, err := proxy.AddToxic(context.Background(), tt.toxic)
+		require.NoError(t, err)
+		assert.Equal(t, "latency_downstream", name)
+
+		require.Len(t, *requests, 1)
+		assert.Equal(t, tt.expected, (*requests)[0].body)
+	}
+
+	require.NoError(t, proxy.RemoveToxic(context.Background(), "latency_downstream"))
+
+	require.NoError(t, proxy.Disable(context.Background()))
+	assert.False(t, proxy.Enabled)
+	assert.Equal(t, map[string]interface{}{"enabled": false}, (*requests)[len(*requests)-1].body)
+}
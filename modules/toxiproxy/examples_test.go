@@ -0,0 +1,37 @@
+package toxiproxy_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/toxiproxy"
+)
+
+func ExampleRunContainer() {
+	// runToxiproxyContainer {
+	ctx := context.Background()
+
+	toxiproxyContainer, err := toxiproxy.RunContainer(ctx, testcontainers.WithImage("ghcr.io/shopify/toxiproxy:2.7.0"))
+	if err != nil {
+		panic(err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := toxiproxyContainer.Terminate(ctx); err != nil {
+			panic(err)
+		}
+	}()
+	// }
+
+	state, err := toxiproxyContainer.State(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
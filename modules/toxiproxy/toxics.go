@@ -0,0 +1,124 @@
+package toxiproxy
+
+import (
+	"time"
+)
+
+// Stream is the direction of the traffic a toxic applies to
+type Stream string
+
+const (
+	// Downstream is the traffic from the upstream server to the client, used by default
+	Downstream Stream = "downstream"
+	// Upstream is the traffic from the client to the upstream server
+	Upstream Stream = "upstream"
+)
+
+// Toxic is a condition applied to the traffic of a proxy, such as Latency or Timeout
+type Toxic interface {
+	spec() toxicSpec
+}
+
+// ToxicOptions are the options shared by all the toxics
+type ToxicOptions struct {
+	// Name of the toxic, generated from its type and stream if empty, e.g. latency_downstream
+	Name string
+	// Stream the toxic applies to, Downstream if empty
+	Stream Stream
+	// Toxicity is the probability of the toxic being applied to a connection, 1 if zero
+	Toxicity float32
+}
+
+// toxicSpec is the representation of a toxic in the HTTP API
+type toxicSpec struct {
+	Name       string                 `json:"name,omitempty"`
+	Type       string                 `json:"type"`
+	Stream     Stream                 `json:"stream"`
+	Toxicity   float32                `json:"toxicity"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+func (o ToxicOptions) spec(typ string, attributes map[string]interface{}) toxicSpec {
+	spec := toxicSpec{
+		Name:       o.Name,
+		Type:       typ,
+		Stream:     o.Stream,
+		Toxicity:   o.Toxicity,
+		Attributes: attributes,
+	}
+	if spec.Stream == "" {
+		spec.Stream = Downstream
+	}
+	if spec.Toxicity == 0 {
+		spec.Toxicity = 1
+	}
+	return spec
+}
+
+// Latency delays the data by the latency, varying randomly up to the jitter
+type Latency struct {
+	ToxicOptions
+	Latency time.Duration
+	Jitter  time.Duration
+}
+
+func (t Latency) spec() toxicSpec {
+	return t.ToxicOptions.spec("latency", map[string]interface{}{
+		"latency": t.Latency.Milliseconds(),
+		"jitter":  t.Jitter.Milliseconds(),
+	})
+}
+
+// Bandwidth limits the rate of the data, in kilobytes per second
+type Bandwidth struct {
+	ToxicOptions
+	Rate int64
+}
+
+func (t Bandwidth) spec() toxicSpec {
+	return t.ToxicOptions.spec("bandwidth", map[string]interface{}{
+		"rate": t.Rate,
+	})
+}
+
+// Timeout stops all the data, and closes the connection after the timeout.
+// If the timeout is zero, the connection is never closed.
+type Timeout struct {
+	ToxicOptions
+	Timeout time.Duration
+}
+
+func (t Timeout) spec() toxicSpec {
+	return t.ToxicOptions.spec("timeout", map[string]interface{}{
+		"timeout": t.Timeout.Milliseconds(),
+	})
+}
+
+// Slicer slices the data into packets of the average size, varying randomly up to the size variation,
+// with a delay between the packets
+type Slicer struct {
+	ToxicOptions
+	AverageSize   int
+	SizeVariation int
+	Delay         time.Duration
+}
+
+func (t Slicer) spec() toxicSpec {
+	return t.ToxicOptions.spec("slicer", map[string]interface{}{
+		"average_size":   t.AverageSize,
+		"size_variation": t.SizeVariation,
+		"delay":          t.Delay.Microseconds(),
+	})
+}
+
+// ResetPeer resets the connection with a TCP RST after the timeout, or immediately if the timeout is zero
+type ResetPeer struct {
+	ToxicOptions
+	Timeout time.Duration
+}
+
+func (t ResetPeer) spec() toxicSpec {
+	return t.ToxicOptions.spec("reset_peer", map[string]interface{}{
+		"timeout": t.Timeout.Milliseconds(),
+	})
+}
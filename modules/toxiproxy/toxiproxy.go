@@ -0,0 +1,193 @@
+package toxiproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// ControlPort is the port of the HTTP API managing the proxies
+	ControlPort = "8474/tcp"
+
+	// firstProxiedPort is the first of the ports exposed for the proxies
+	firstProxiedPort = 8666
+	// maxProxies is the number of ports exposed for the proxies
+	maxProxies = 31
+)
+
+// ToxiproxyContainer represents the Toxiproxy container type used in the module
+type ToxiproxyContainer struct {
+	testcontainers.Container
+
+	mtx      sync.Mutex
+	nextPort int
+}
+
+// RunContainer creates an instance of the Toxiproxy container type
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*ToxiproxyContainer, error) {
+	exposedPorts := []string{ControlPort}
+	for i := 0; i < maxProxies; i++ {
+		exposedPorts = append(exposedPorts, fmt.Sprintf("%d/tcp", firstProxiedPort+i))
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        "ghcr.io/shopify/toxiproxy:2.7.0",
+		ExposedPorts: exposedPorts,
+		WaitingFor:   wait.ForHTTP("/version").WithPort(ControlPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ToxiproxyContainer{Container: container, nextPort: firstProxiedPort}, nil
+}
+
+// URI returns the URI of the HTTP API managing the proxies, e.g. http://localhost:32768
+func (c *ToxiproxyContainer) URI(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, ControlPort, "http")
+}
+
+// Client returns a client of the HTTP API managing the proxies
+func (c *ToxiproxyContainer) Client(ctx context.Context) (*Client, error) {
+	uri, err := c.URI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(uri), nil
+}
+
+// CreateProxy creates a proxy to the upstream address, which must be reachable from the Toxiproxy container,
+// e.g. "redis:6379" for a container with the redis alias on a network shared with Toxiproxy.
+// The proxy listens on one of the ports exposed by the container, see ProxyEndpoint.
+func (c *ToxiproxyContainer) CreateProxy(ctx context.Context, name string, upstream string) (*Proxy, error) {
+	client, err := c.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.nextPort >= firstProxiedPort+maxProxies {
+		return nil, fmt.Errorf("all the %d proxied ports are used", maxProxies)
+	}
+
+	proxy, err := client.CreateProxy(ctx, name, fmt.Sprintf("0.0.0.0:%d", c.nextPort), upstream)
+	if err != nil {
+		return nil, err
+	}
+	c.nextPort++
+
+	return proxy, nil
+}
+
+// ProxyEndpoint returns the host:port address from which the tests can connect to the proxy
+func (c *ToxiproxyContainer) ProxyEndpoint(ctx context.Context, proxy *Proxy) (string, error) {
+	_, port, err := net.SplitHostPort(proxy.Listen)
+	if err != nil {
+		return "", fmt.Errorf("invalid listen address %q: %w", proxy.Listen, err)
+	}
+
+	mappedPort, err := c.MappedPort(ctx, nat.Port(port+"/tcp"))
+	if err != nil {
+		return "", err
+	}
+
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, mappedPort.Port()), nil
+}
+
+// ProxiedEndpoint creates a proxy to the port of the target container, returning it with the host:port
+// address from which the tests can connect to the target through Toxiproxy, so that the connection
+// string of any module can be routed through the proxy. The target must share a network with Toxiproxy.
+func (c *ToxiproxyContainer) ProxiedEndpoint(ctx context.Context, target testcontainers.Container, port nat.Port) (*Proxy, string, error) {
+	host, err := c.upstreamHost(ctx, target)
+	if err != nil {
+		return nil, "", err
+	}
+
+	name, err := target.Name(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	name = strings.TrimPrefix(name, "/") + "_" + port.Port()
+
+	proxy, err := c.CreateProxy(ctx, name, net.JoinHostPort(host, port.Port()))
+	if err != nil {
+		return nil, "", err
+	}
+
+	endpoint, err := c.ProxyEndpoint(ctx, proxy)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return proxy, endpoint, nil
+}
+
+// upstreamHost returns the host from which the Toxiproxy container can reach the target container,
+// which is its first alias, or its name, on the first network they share
+func (c *ToxiproxyContainer) upstreamHost(ctx context.Context, target testcontainers.Container) (string, error) {
+	networks, err := c.Networks(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	targetNetworks, err := target.Networks(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, nw := range networks {
+		for _, targetNetwork := range targetNetworks {
+			if nw != targetNetwork {
+				continue
+			}
+
+			// the default bridge network does not resolve the names of the containers
+			if nw == "bridge" {
+				return target.ContainerIP(ctx)
+			}
+
+			aliases, err := target.NetworkAliases(ctx)
+			if err != nil {
+				return "", err
+			}
+			if len(aliases[nw]) > 0 {
+				return aliases[nw][0], nil
+			}
+
+			name, err := target.Name(ctx)
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimPrefix(name, "/"), nil
+		}
+	}
+
+	return "", errors.New("the container does not share any network with the Toxiproxy container")
+}
@@ -0,0 +1,133 @@
+package toxiproxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestToxiproxy(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := nw.Remove(ctx); err != nil {
+			t.Fatalf("failed to remove network: %s", err)
+		}
+	})
+
+	container, err := RunContainer(ctx, network.WithNetwork([]string{"toxiproxy"}, nw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	redisContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:          "redis:7",
+			ExposedPorts:   []string{"6379/tcp"},
+			Networks:       []string{nw.Name},
+			NetworkAliases: map[string][]string{nw.Name: {"redis"}},
+			WaitingFor:     wait.ForLog("Ready to accept connections"),
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := redisContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// proxiedEndpoint {
+	proxy, endpoint, err := container.ProxiedEndpoint(ctx, redisContainer, "6379/tcp")
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proxy.Upstream != "redis:6379" {
+		t.Fatalf("expected upstream redis:6379, got %s", proxy.Upstream)
+	}
+
+	redisClient := redis.NewClient(&redis.Options{Addr: endpoint, ReadTimeout: 2 * time.Second})
+	defer redisClient.Close()
+
+	if err := redisClient.Set(ctx, "key", "value", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("latency", func(t *testing.T) {
+		// addToxic {
+		name, err := proxy.AddToxic(ctx, Latency{Latency: 500 * time.Millisecond})
+		// }
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		start := time.Now()
+		if err := redisClient.Get(ctx, "key").Err(); err != nil {
+			t.Fatal(err)
+		}
+		if elapsed := time.Since(start); elapsed < 500*time.Millisecond {
+			t.Fatalf("expected latency of at least 500ms, got %s", elapsed)
+		}
+
+		if err := proxy.RemoveToxic(ctx, name); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		if _, err := proxy.AddToxic(ctx, Timeout{}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := redisClient.Get(ctx, "key").Err(); err == nil {
+			t.Fatal("expected the request to time out")
+		}
+
+		client, err := container.Client(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := client.ResetState(ctx); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		if err := proxy.Disable(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := redisClient.Get(ctx, "key").Err(); err == nil {
+			t.Fatal("expected the request to fail")
+		}
+
+		if err := proxy.Enable(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := redisClient.Get(ctx, "key").Err(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -29,7 +29,10 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		HostConfigModifier: func(hc *container.HostConfig) {
 			hc.CapAdd = []string{"IPC_LOCK"}
 		},
-		WaitingFor: wait.ForHTTP("/v1/sys/health").WithPort(defaultPort),
+		WaitingFor: wait.ForHTTP("/v1/sys/health").
+			WithPort(defaultPort).
+			WithJSONMatcher("$.initialized == true").
+			WithJSONMatcher("$.sealed == false"),
 		Env: map[string]string{
 			"VAULT_ADDR": "http://0.0.0.0:" + defaultPort,
 		},
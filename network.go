@@ -2,6 +2,8 @@ package testcontainers
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/network"
@@ -11,6 +13,7 @@ import (
 type NetworkProvider interface {
 	CreateNetwork(context.Context, NetworkRequest) (Network, error)            // create a network
 	GetNetwork(context.Context, NetworkRequest) (types.NetworkResource, error) // get a network
+	ReuseOrCreateNetwork(context.Context, NetworkRequest) (Network, error)     // reuses a network of the session if it exists or creates it
 }
 
 // Deprecated: will be removed in the future
@@ -43,8 +46,85 @@ type NetworkRequest struct {
 	Labels         map[string]string
 	Attachable     bool
 	IPAM           *network.IPAM
+	LifecycleHooks []NetworkLifecycleHooks // define hooks to be executed during network lifecycle
 
 	SkipReaper    bool              // Deprecated: The reaper is globally controlled by the .testcontainers.properties file or the TESTCONTAINERS_RYUK_DISABLED environment variable
 	ReaperImage   string            // Deprecated: use WithImageName ContainerOption instead. Alternative reaper registry
 	ReaperOptions []ContainerOption // Deprecated: the reaper is configured at the properties level, for an entire test session
 }
+
+// NetworkInfo is a view of a Docker network, returned by DockerNetwork.Inspect
+type NetworkInfo struct {
+	ID         string
+	Name       string
+	Driver     string
+	Internal   bool
+	Attachable bool
+	EnableIPv6 bool
+	Subnets    []NetworkSubnet
+	Containers []NetworkContainer // sorted by name
+	Options    map[string]string  // options of the network driver
+	Labels     map[string]string
+}
+
+// NetworkSubnet is a subnet of a Docker network, in CIDR notation
+type NetworkSubnet struct {
+	Subnet  string // e.g. 172.20.0.0/16
+	Gateway string // e.g. 172.20.0.1
+	IPRange string // range of the subnet the IP addresses are allocated from, if set
+}
+
+// NetworkContainer is a container connected to a Docker network
+type NetworkContainer struct {
+	ID          string
+	Name        string
+	IPv4Address string // without the prefix length, e.g. 172.20.0.2
+	IPv6Address string // without the prefix length, empty if IPv6 is not enabled
+	MacAddress  string
+	Aliases     []string // aliases of the container on the network
+}
+
+// newNetworkInfo converts the network resource returned by Docker, which doesn't include the
+// aliases of the containers, so they are passed by container ID
+func newNetworkInfo(resource types.NetworkResource, aliases map[string][]string) *NetworkInfo {
+	info := &NetworkInfo{
+		ID:         resource.ID,
+		Name:       resource.Name,
+		Driver:     resource.Driver,
+		Internal:   resource.Internal,
+		Attachable: resource.Attachable,
+		EnableIPv6: resource.EnableIPv6,
+		Options:    resource.Options,
+		Labels:     resource.Labels,
+	}
+
+	for _, config := range resource.IPAM.Config {
+		info.Subnets = append(info.Subnets, NetworkSubnet{
+			Subnet:  config.Subnet,
+			Gateway: config.Gateway,
+			IPRange: config.IPRange,
+		})
+	}
+
+	for id, endpoint := range resource.Containers {
+		info.Containers = append(info.Containers, NetworkContainer{
+			ID:          id,
+			Name:        endpoint.Name,
+			IPv4Address: stripPrefixLength(endpoint.IPv4Address),
+			IPv6Address: stripPrefixLength(endpoint.IPv6Address),
+			MacAddress:  endpoint.MacAddress,
+			Aliases:     aliases[id],
+		})
+	}
+	sort.Slice(info.Containers, func(i, j int) bool {
+		return info.Containers[i].Name < info.Containers[j].Name
+	})
+
+	return info
+}
+
+// stripPrefixLength removes the prefix length from an address in CIDR notation, e.g. 172.20.0.2/16
+func stripPrefixLength(address string) string {
+	ip, _, _ := strings.Cut(address, "/")
+	return ip
+}
@@ -0,0 +1,151 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// ServiceDialer dials the addresses the containers of a network use to reach each other, e.g. kafka:9092,
+// through the ports of the containers mapped on the host, so the client libraries configured with those
+// addresses, e.g. from the advertised listeners of Kafka, can be used from the tests unchanged.
+type ServiceDialer struct {
+	network *testcontainers.DockerNetwork
+	dialer  net.Dialer
+
+	mu       sync.Mutex
+	provider *testcontainers.DockerProvider
+}
+
+// Dialer returns a dialer for the addresses of the containers of the network, by alias, name or IP address
+// on the network, which can be used as the DialContext function of an http.Transport, or as the dial
+// function of a database driver. The rest of the addresses are dialed unchanged.
+// The dialer must be closed once it's not needed anymore.
+func Dialer(nw *testcontainers.DockerNetwork) *ServiceDialer {
+	return &ServiceDialer{network: nw}
+}
+
+// DialContext connects to the address on the named network, e.g. "tcp", resolving the address
+// of a container of the network to the host and port it's mapped to
+func (d *ServiceDialer) DialContext(ctx context.Context, network string, address string) (net.Conn, error) {
+	resolved, err := d.Resolve(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.dialer.DialContext(ctx, network, resolved)
+}
+
+// Dial connects to the address on the named network, see DialContext
+func (d *ServiceDialer) Dial(network string, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+// Resolve returns the host and port the address of a container of the network is mapped to,
+// or the address itself if it doesn't belong to a container of the network, e.g. for clients
+// which receive an address instead of a dial function
+func (d *ServiceDialer) Resolve(ctx context.Context, network string, address string) (string, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", err
+	}
+
+	containers, err := d.network.Containers(ctx)
+	if err != nil {
+		return "", fmt.Errorf("inspect network %s: %w", d.network.Name, err)
+	}
+
+	var containerID string
+	for _, c := range containers {
+		if matchesContainer(c, host) {
+			containerID = c.ID
+			break
+		}
+	}
+	if containerID == "" {
+		return address, nil
+	}
+
+	provider, err := d.dockerProvider()
+	if err != nil {
+		return "", err
+	}
+
+	inspect, err := provider.Client().ContainerInspect(ctx, containerID)
+	if err != nil {
+		return "", err
+	}
+
+	proto := "tcp"
+	if strings.HasPrefix(network, "udp") {
+		proto = "udp"
+	}
+
+	containerPort := nat.Port(port + "/" + proto)
+	for _, binding := range inspect.NetworkSettings.Ports[containerPort] {
+		if binding.HostPort == "" {
+			continue
+		}
+
+		daemonHost, err := provider.DaemonHost(ctx)
+		if err != nil {
+			return "", err
+		}
+		return net.JoinHostPort(daemonHost, binding.HostPort), nil
+	}
+
+	return "", fmt.Errorf("port %s of %s is not mapped on the host, please expose it", containerPort, host)
+}
+
+// Close releases the connection to the Docker daemon used to inspect the containers,
+// once the dialer is not needed anymore
+func (d *ServiceDialer) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.provider == nil {
+		return nil
+	}
+
+	err := d.provider.Close()
+	d.provider = nil
+
+	return err
+}
+
+// dockerProvider returns the provider used to inspect the containers, creating it the first time
+func (d *ServiceDialer) dockerProvider() (*testcontainers.DockerProvider, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.provider == nil {
+		provider, err := testcontainers.NewDockerProvider()
+		if err != nil {
+			return nil, err
+		}
+		d.provider = provider
+	}
+
+	return d.provider, nil
+}
+
+// matchesContainer returns true if the host is the name, an alias or the IP address of the container on the network
+func matchesContainer(c testcontainers.NetworkContainer, host string) bool {
+	if host == c.Name || host == c.IPv4Address || host == c.IPv6Address {
+		return true
+	}
+
+	for _, alias := range c.Aliases {
+		if host == alias {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package network_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestDialer(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nw.Remove(ctx))
+	})
+
+	nginx, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:          nginxAlpineImage,
+			ExposedPorts:   []string{nginxDefaultPort},
+			Networks:       []string{nw.Name},
+			NetworkAliases: map[string][]string{nw.Name: {"web"}},
+			WaitingFor:     wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nginx.Terminate(ctx))
+	})
+
+	// networkDialer {
+	dialer := network.Dialer(nw)
+	defer dialer.Close()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: dialer.DialContext,
+		},
+	}
+
+	// web is the alias of the nginx container on the network
+	resp, err := client.Get("http://web")
+	// }
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	t.Run("address on the network", func(t *testing.T) {
+		ip, err := nw.IPOf(ctx, nginx)
+		require.NoError(t, err)
+
+		endpoint, err := nginx.PortEndpoint(ctx, nginxDefaultPort, "")
+		require.NoError(t, err)
+
+		resolved, err := dialer.Resolve(ctx, "tcp", ip+":80")
+		require.NoError(t, err)
+		assert.Equal(t, endpoint, resolved)
+	})
+
+	t.Run("port not mapped", func(t *testing.T) {
+		_, err := dialer.Resolve(ctx, "tcp", "web:8080")
+		require.EqualError(t, err, "port 8080/tcp of web is not mapped on the host, please expose it")
+	})
+
+	t.Run("address outside the network", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		t.Cleanup(server.Close)
+
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
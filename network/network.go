@@ -17,31 +17,57 @@ import (
 // - Driver: bridge
 // - Labels: the Testcontainers for Go generic labels, to be managed by Ryuk. Please see the GenericLabels() function
 // And those options can be modified by the user, using the CreateModifier function field.
+// The name of the network can be set with WithName, and the network reused with WithReuse.
 func New(ctx context.Context, opts ...NetworkCustomizer) (*testcontainers.DockerNetwork, error) {
 	nc := types.NetworkCreate{
 		Driver: "bridge",
 		Labels: testcontainers.GenericLabels(),
 	}
 
+	nr := networkRequest{}
+
 	for _, opt := range opts {
+		if reqOpt, ok := opt.(NetworkRequestOption); ok {
+			reqOpt(&nr)
+			continue
+		}
 		opt.Customize(&nc)
 	}
 
+	if nr.name == "" {
+		// a random name would never be shared by the network requests reusing it
+		if nr.reuse {
+			return nil, testcontainers.ErrReuseEmptyNetworkName
+		}
+		nr.name = uuid.NewString()
+	}
+
+	if needsSubnets(nc.IPAM) {
+		return newWithSubnets(ctx, nc, nr)
+	}
+
+	return newNetwork(ctx, nc, nr)
+}
+
+// newNetwork creates the network from the create request, or reuses it
+func newNetwork(ctx context.Context, nc types.NetworkCreate, nr networkRequest) (*testcontainers.DockerNetwork, error) {
 	//nolint:staticcheck
 	netReq := testcontainers.NetworkRequest{
 		Driver:         nc.Driver,
 		CheckDuplicate: nc.CheckDuplicate,
 		Internal:       nc.Internal,
 		EnableIPv6:     nc.EnableIPv6,
-		Name:           uuid.NewString(),
+		Name:           nr.name,
 		Labels:         nc.Labels,
 		Attachable:     nc.Attachable,
 		IPAM:           nc.IPAM,
+		LifecycleHooks: nr.lifecycleHooks,
 	}
 
 	//nolint:staticcheck
 	n, err := testcontainers.GenericNetwork(ctx, testcontainers.GenericNetworkRequest{
 		NetworkRequest: netReq,
+		Reuse:          nr.reuse,
 	})
 	if err != nil {
 		return nil, err
@@ -66,6 +92,45 @@ func (opt CustomizeNetworkOption) Customize(req *types.NetworkCreate) {
 	opt(req)
 }
 
+// networkRequest holds the options of the network which are not part of the Docker create request
+type networkRequest struct {
+	name           string
+	reuse          bool
+	lifecycleHooks []testcontainers.NetworkLifecycleHooks
+}
+
+// NetworkRequestOption is a type that can be used to configure how the network is created,
+// beyond the network create request, e.g. its name. Those options are applied by New.
+type NetworkRequestOption func(req *networkRequest)
+
+// Customize implements the NetworkCustomizer interface. It doesn't modify the network create request,
+// as New applies the option to the rest of the network options instead.
+func (opt NetworkRequestOption) Customize(_ *types.NetworkCreate) {}
+
+// WithName allows to set the name of the network, instead of a random UUID name.
+func WithName(name string) NetworkRequestOption {
+	return func(req *networkRequest) {
+		req.name = name
+	}
+}
+
+// WithReuse allows to reuse the network with the same name, e.g. created by another package of the
+// test session, or left by an earlier one, instead of creating a new one. The name must be set with WithName.
+// A network created by the test session is removed by Ryuk when the session ends, so removing it is not needed.
+func WithReuse() NetworkRequestOption {
+	return func(req *networkRequest) {
+		req.reuse = true
+	}
+}
+
+// WithLifecycleHooks allows to set the hooks to be executed when the network is created and removed,
+// which are added to the default logging hook.
+func WithLifecycleHooks(hooks ...testcontainers.NetworkLifecycleHooks) NetworkRequestOption {
+	return func(req *networkRequest) {
+		req.lifecycleHooks = append(req.lifecycleHooks, hooks...)
+	}
+}
+
 // WithAttachable allows to set the network as attachable.
 func WithAttachable() CustomizeNetworkOption {
 	return func(original *types.NetworkCreate) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/netip"
 	"testing"
 	"time"
 
@@ -229,6 +230,208 @@ func TestContainerIPs(t *testing.T) {
 	}
 }
 
+func TestNetworkInspect(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx, network.WithIPAM(&dockernetwork.IPAM{
+		Config: []dockernetwork.IPAMConfig{{Subnet: "10.1.2.0/24", Gateway: "10.1.2.254"}},
+	}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nw.Remove(ctx))
+	})
+
+	nginx, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:          nginxAlpineImage,
+			Networks:       []string{nw.Name},
+			NetworkAliases: map[string][]string{nw.Name: {"web"}},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nginx.Terminate(ctx))
+	})
+
+	// inspectNetwork {
+	info, err := nw.Inspect(ctx)
+	// }
+	require.NoError(t, err)
+
+	assert.Equal(t, nw.Name, info.Name)
+	assert.Equal(t, []testcontainers.NetworkSubnet{{Subnet: "10.1.2.0/24", Gateway: "10.1.2.254"}}, info.Subnets)
+	require.Len(t, info.Containers, 1)
+	assert.Equal(t, nginx.GetContainerID(), info.Containers[0].ID)
+	assert.Contains(t, info.Containers[0].Aliases, "web")
+
+	containers, err := nw.Containers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, info.Containers, containers)
+
+	// ipOf {
+	ip, err := nw.IPOf(ctx, nginx)
+	// }
+	require.NoError(t, err)
+	assert.Equal(t, info.Containers[0].IPv4Address, ip)
+	assert.Contains(t, ip, "10.1.2.")
+
+	other, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, other.Remove(ctx))
+	})
+
+	_, err = other.IPOf(ctx, nginx)
+	require.Error(t, err)
+}
+
+func TestNew_withSubnet(t *testing.T) {
+	ctx := context.Background()
+
+	// staticIP {
+	nw, err := network.New(ctx, network.WithSubnet("/24"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		require.NoError(t, nw.Remove(ctx))
+	})
+
+	info, err := nw.Inspect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the static IP address belongs to the allocated subnet, e.g. 10.128.0.10
+	prefix := netip.MustParsePrefix(info.Subnets[0].Subnet)
+	addr := prefix.Addr().As4()
+	addr[3] = 10
+	ip := netip.AddrFrom4(addr).String()
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	}
+	network.WithStaticIP(nw, ip, "seed")(&req)
+
+	seed, err := testcontainers.GenericContainer(ctx, req)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, seed.Terminate(ctx))
+	})
+
+	assert.Equal(t, 24, prefix.Bits())
+	assert.True(t, netip.MustParsePrefix("10.128.0.0/9").Contains(prefix.Addr()))
+
+	seedIP, err := nw.IPOf(ctx, seed)
+	require.NoError(t, err)
+	assert.Equal(t, ip, seedIP)
+}
+
+func TestNew_withSubnetInParallel(t *testing.T) {
+	ctx := context.Background()
+
+	type result struct {
+		nw  *testcontainers.DockerNetwork
+		err error
+	}
+
+	results := make(chan result, 5)
+	for i := 0; i < 5; i++ {
+		go func() {
+			nw, err := network.New(ctx, network.WithSubnet("/24"))
+			results <- result{nw: nw, err: err}
+		}()
+	}
+
+	subnets := map[string]bool{}
+	for i := 0; i < 5; i++ {
+		r := <-results
+		require.NoError(t, r.err)
+		nw := r.nw
+		t.Cleanup(func() {
+			require.NoError(t, nw.Remove(ctx))
+		})
+
+		info, err := nw.Inspect(ctx)
+		require.NoError(t, err)
+		subnets[info.Subnets[0].Subnet] = true
+	}
+
+	assert.Len(t, subnets, 5)
+}
+
+func TestNew_withReuse(t *testing.T) {
+	ctx := context.Background()
+
+	var events []string
+	hooks := testcontainers.NetworkLifecycleHooks{
+		PostCreates: []testcontainers.NetworkHook{
+			func(ctx context.Context, nw *testcontainers.DockerNetwork) error {
+				events = append(events, "created "+nw.Name)
+				return nil
+			},
+		},
+		PostRemoves: []testcontainers.NetworkHook{
+			func(ctx context.Context, nw *testcontainers.DockerNetwork) error {
+				events = append(events, "removed "+nw.Name)
+				return nil
+			},
+		},
+	}
+
+	// reuseNetwork {
+	name := "test-reused-network"
+
+	nw, err := network.New(ctx,
+		network.WithName(name),
+		network.WithReuse(),
+		network.WithLifecycleHooks(hooks),
+	)
+	// }
+	require.NoError(t, err)
+
+	reused, err := network.New(ctx, network.WithName(name), network.WithReuse(), network.WithLifecycleHooks(hooks))
+	require.NoError(t, err)
+
+	assert.Equal(t, name, nw.Name)
+	assert.Equal(t, nw.ID, reused.ID)
+
+	require.NoError(t, reused.Remove(ctx))
+	assert.Equal(t, []string{"created " + name, "removed " + name}, events)
+}
+
+func TestNew_withReuseOfStaleNetwork(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := testcontainers.NewDockerClientWithOpts(ctx)
+	require.NoError(t, err)
+	defer client.Close()
+
+	// a network with the same name left by an earlier test session, not removed by its reaper yet
+	name := "test-stale-reused-network"
+	stale, err := client.NetworkCreate(ctx, name, types.NetworkCreate{
+		Labels: map[string]string{testcontainersdocker.LabelSessionID: "earlier-session"},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.NetworkRemove(ctx, stale.ID))
+	})
+
+	nw, err := network.New(ctx, network.WithName(name), network.WithReuse())
+	require.NoError(t, err)
+	assert.Equal(t, stale.ID, nw.ID)
+}
+
+func TestNew_withReuseWithoutName(t *testing.T) {
+	nw, err := network.New(context.Background(), network.WithReuse())
+	require.ErrorIs(t, err, testcontainers.ErrReuseEmptyNetworkName)
+	assert.Nil(t, nw)
+}
+
 func TestContainerWithReaperNetwork(t *testing.T) {
 	if testcontainersdocker.IsWindows() {
 		t.Skip("Skip for Windows. See https://stackoverflow.com/questions/43784916/docker-for-windows-networking-container-with-multiple-network-interfaces")
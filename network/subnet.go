@@ -0,0 +1,190 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// subnetPool is the range the subnets requested with a prefix length only are allocated from.
+// It doesn't overlap the default address pools of Docker, 172.17.0.0/12 and 192.168.0.0/16.
+var subnetPool = netip.MustParsePrefix("10.128.0.0/9")
+
+// maxSubnetAttempts is the number of times a network with allocated subnets is created,
+// when another test session creates a network with an overlapping subnet in the meantime
+const maxSubnetAttempts = 5
+
+// subnetMu serialises the allocation and creation of networks with allocated subnets
+// across the parallel tests of a session
+var subnetMu sync.Mutex
+
+// WithSubnet sets a subnet of the network, in CIDR notation, e.g. "10.1.2.0/24".
+// If only the prefix length is set, e.g. "/24", a free subnet of that size, not overlapping
+// the subnets of the existing networks, is allocated when the network is created.
+// It can be combined with WithStaticIP to give predictable IP addresses to the containers.
+func WithSubnet(cidr string) CustomizeNetworkOption {
+	return func(original *types.NetworkCreate) {
+		if original.IPAM == nil {
+			original.IPAM = &network.IPAM{}
+		}
+		original.IPAM.Config = append(original.IPAM.Config, network.IPAMConfig{Subnet: cidr})
+	}
+}
+
+// WithStaticIP attaches the container to the network with a static IPv4 or IPv6 address,
+// and the given aliases. The address must belong to a subnet of the network, e.g. set with WithSubnet.
+func WithStaticIP(nw *testcontainers.DockerNetwork, ip string, aliases ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		WithNetwork(aliases, nw)(req)
+
+		ipamConfig := &network.EndpointIPAMConfig{IPv4Address: ip}
+		if strings.Contains(ip, ":") {
+			ipamConfig = &network.EndpointIPAMConfig{IPv6Address: ip}
+		}
+
+		modifier := req.EnpointSettingsModifier
+		req.EnpointSettingsModifier = func(settings map[string]*network.EndpointSettings) {
+			if modifier != nil {
+				modifier(settings)
+			}
+
+			endpoint, ok := settings[nw.Name]
+			if !ok {
+				endpoint = &network.EndpointSettings{NetworkID: nw.ID, Aliases: aliases}
+				settings[nw.Name] = endpoint
+			}
+			endpoint.IPAMConfig = ipamConfig
+		}
+	}
+}
+
+// needsSubnets returns true if any of the subnets of the network has to be allocated
+func needsSubnets(ipam *network.IPAM) bool {
+	if ipam == nil {
+		return false
+	}
+
+	for _, config := range ipam.Config {
+		if strings.HasPrefix(config.Subnet, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// newWithSubnets creates the network allocating its subnets, retrying if the allocated subnets
+// overlap the subnet of a network created in the meantime by another test session
+func newWithSubnets(ctx context.Context, nc types.NetworkCreate, nr networkRequest) (*testcontainers.DockerNetwork, error) {
+	subnetMu.Lock()
+	defer subnetMu.Unlock()
+
+	cli, err := testcontainers.NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+
+	var lastErr error
+	for i := 0; i < maxSubnetAttempts; i++ {
+		networks, err := cli.NetworkList(ctx, types.NetworkListOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		var used []netip.Prefix
+		for _, n := range networks {
+			for _, config := range n.IPAM.Config {
+				if prefix, err := netip.ParsePrefix(config.Subnet); err == nil {
+					used = append(used, prefix)
+				}
+			}
+		}
+
+		req := nc
+		req.IPAM, err = allocateSubnets(nc.IPAM, used)
+		if err != nil {
+			return nil, err
+		}
+
+		nw, err := newNetwork(ctx, req, nr)
+		if err == nil {
+			return nw, nil
+		}
+		if !strings.Contains(err.Error(), "overlaps") {
+			return nil, err
+		}
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("allocate subnets after %d attempts: %w", maxSubnetAttempts, lastErr)
+}
+
+// allocateSubnets returns a copy of the IPAM configuration, replacing the subnets set with a prefix length
+// only with the first free subnets of the pool, not overlapping the used ones nor each other
+func allocateSubnets(ipam *network.IPAM, used []netip.Prefix) (*network.IPAM, error) {
+	allocated := *ipam
+	allocated.Config = make([]network.IPAMConfig, len(ipam.Config))
+	copy(allocated.Config, ipam.Config)
+
+	used = append([]netip.Prefix{}, used...)
+	for _, config := range allocated.Config {
+		if prefix, err := netip.ParsePrefix(config.Subnet); err == nil {
+			used = append(used, prefix)
+		}
+	}
+
+	for i, config := range allocated.Config {
+		if !strings.HasPrefix(config.Subnet, "/") {
+			continue
+		}
+
+		bits, err := strconv.Atoi(strings.TrimPrefix(config.Subnet, "/"))
+		if err != nil || bits < subnetPool.Bits() || bits > 30 {
+			return nil, fmt.Errorf("invalid subnet %q: the prefix length must be between %d and 30", config.Subnet, subnetPool.Bits())
+		}
+
+		subnet, err := freeSubnet(bits, used)
+		if err != nil {
+			return nil, err
+		}
+
+		allocated.Config[i].Subnet = subnet.String()
+		used = append(used, subnet)
+	}
+
+	return &allocated, nil
+}
+
+// freeSubnet returns the first subnet of the pool with the given prefix length not overlapping the used ones
+func freeSubnet(bits int, used []netip.Prefix) (netip.Prefix, error) {
+	size := uint32(1) << (32 - bits)
+	start := subnetPool.Addr().As4()
+	base := uint32(start[0])<<24 | uint32(start[1])<<16 | uint32(start[2])<<8 | uint32(start[3])
+	count := uint32(1) << (bits - subnetPool.Bits())
+
+	for i := uint32(0); i < count; i++ {
+		addr := base + i*size
+		candidate := netip.PrefixFrom(netip.AddrFrom4([4]byte{byte(addr >> 24), byte(addr >> 16), byte(addr >> 8), byte(addr)}), bits)
+
+		overlaps := false
+		for _, prefix := range used {
+			if candidate.Overlaps(prefix) {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			return candidate, nil
+		}
+	}
+
+	return netip.Prefix{}, fmt.Errorf("no free /%d subnet in %s", bits, subnetPool)
+}
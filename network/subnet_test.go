@@ -0,0 +1,87 @@
+package network
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func TestAllocateSubnets(t *testing.T) {
+	used := []netip.Prefix{
+		netip.MustParsePrefix("172.17.0.0/16"),
+		netip.MustParsePrefix("10.128.0.0/24"),
+		netip.MustParsePrefix("10.128.2.0/23"),
+	}
+
+	ipam := &network.IPAM{
+		Config: []network.IPAMConfig{
+			{Subnet: "/24"},
+			{Subnet: "10.128.1.0/24"},
+			{Subnet: "/24"},
+			{Subnet: "/16"},
+		},
+	}
+
+	allocated, err := allocateSubnets(ipam, used)
+	require.NoError(t, err)
+
+	assert.Equal(t, []network.IPAMConfig{
+		{Subnet: "10.128.4.0/24"},
+		{Subnet: "10.128.1.0/24"},
+		{Subnet: "10.128.5.0/24"},
+		{Subnet: "10.129.0.0/16"},
+	}, allocated.Config)
+
+	// the original configuration is kept, to be allocated again if the network can't be created
+	assert.Equal(t, "/24", ipam.Config[0].Subnet)
+}
+
+func TestAllocateSubnets_invalid(t *testing.T) {
+	for _, subnet := range []string{"/8", "/31", "/abc"} {
+		_, err := allocateSubnets(&network.IPAM{Config: []network.IPAMConfig{{Subnet: subnet}}}, nil)
+		require.EqualError(t, err, `invalid subnet "`+subnet+`": the prefix length must be between 9 and 30`)
+	}
+}
+
+func TestAllocateSubnets_exhausted(t *testing.T) {
+	_, err := allocateSubnets(&network.IPAM{Config: []network.IPAMConfig{{Subnet: "/10"}}}, []netip.Prefix{
+		netip.MustParsePrefix("10.128.0.0/10"),
+		netip.MustParsePrefix("10.192.0.0/10"),
+	})
+	require.EqualError(t, err, "no free /10 subnet in 10.128.0.0/9")
+}
+
+func TestWithSubnet(t *testing.T) {
+	nc := types.NetworkCreate{}
+
+	WithSubnet("/24")(&nc)
+	WithSubnet("10.1.2.0/24")(&nc)
+
+	assert.True(t, needsSubnets(nc.IPAM))
+	assert.Equal(t, []network.IPAMConfig{{Subnet: "/24"}, {Subnet: "10.1.2.0/24"}}, nc.IPAM.Config)
+}
+
+func TestWithStaticIP(t *testing.T) {
+	nw := &testcontainers.DockerNetwork{ID: "abc123", Name: "cluster"}
+
+	req := testcontainers.GenericContainerRequest{}
+	WithStaticIP(nw, "10.128.4.10", "seed")(&req)
+
+	assert.Equal(t, []string{"cluster"}, req.Networks)
+	assert.Equal(t, map[string][]string{"cluster": {"seed"}}, req.NetworkAliases)
+
+	settings := map[string]*network.EndpointSettings{}
+	req.EnpointSettingsModifier(settings)
+
+	assert.Equal(t, &network.EndpointSettings{
+		NetworkID:  "abc123",
+		Aliases:    []string{"seed"},
+		IPAMConfig: &network.EndpointIPAMConfig{IPv4Address: "10.128.4.10"},
+	}, settings["cluster"])
+}
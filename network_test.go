@@ -0,0 +1,47 @@
+package testcontainers
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNetworkInfo(t *testing.T) {
+	resource := types.NetworkResource{
+		ID:         "abc123",
+		Name:       "backend",
+		Driver:     "bridge",
+		Attachable: true,
+		IPAM: network.IPAM{
+			Config: []network.IPAMConfig{
+				{Subnet: "10.1.1.0/24", Gateway: "10.1.1.254", IPRange: "10.1.1.0/25"},
+			},
+		},
+		Containers: map[string]types.EndpointResource{
+			"id-web": {Name: "web", IPv4Address: "10.1.1.3/24", MacAddress: "02:42:0a:01:01:03"},
+			"id-db":  {Name: "db", IPv4Address: "10.1.1.2/24", IPv6Address: "fd00::2/64"},
+		},
+		Options: map[string]string{"com.docker.network.bridge.enable_icc": "true"},
+		Labels:  map[string]string{"org.testcontainers": "true"},
+	}
+
+	info := newNetworkInfo(resource, map[string][]string{"id-db": {"db", "postgres"}})
+
+	assert.Equal(t, &NetworkInfo{
+		ID:         "abc123",
+		Name:       "backend",
+		Driver:     "bridge",
+		Attachable: true,
+		Subnets: []NetworkSubnet{
+			{Subnet: "10.1.1.0/24", Gateway: "10.1.1.254", IPRange: "10.1.1.0/25"},
+		},
+		Containers: []NetworkContainer{
+			{ID: "id-db", Name: "db", IPv4Address: "10.1.1.2", IPv6Address: "fd00::2", Aliases: []string{"db", "postgres"}},
+			{ID: "id-web", Name: "web", IPv4Address: "10.1.1.3", MacAddress: "02:42:0a:01:01:03"},
+		},
+		Options: map[string]string{"com.docker.network.bridge.enable_icc": "true"},
+		Labels:  map[string]string{"org.testcontainers": "true"},
+	}, info)
+}
@@ -53,6 +53,14 @@ func WithEndpointSettingsModifier(modifier func(settings map[string]*network.End
 	}
 }
 
+// WithHealthCheck sets the health check of the container, overriding the one defined in the image.
+// The container can then be waited for with wait.ForHealthCheck.
+func WithHealthCheck(healthCheck *container.HealthConfig) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.HealthCheck = healthCheck
+	}
+}
+
 // WithHostConfigModifier allows to override the default host config
 func WithHostConfigModifier(modifier func(hostConfig *container.HostConfig)) CustomizeRequestOption {
 	return func(req *GenericContainerRequest) {
@@ -128,6 +136,21 @@ func WithImageSubstitutors(fn ...ImageSubstitutor) CustomizeRequestOption {
 	}
 }
 
+// WithPullPolicy sets the policy deciding whether to pull the image of a container, e.g. PullIfOlderThan(24 * time.Hour)
+func WithPullPolicy(policy PullPolicy) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.PullPolicy = policy
+	}
+}
+
+// WithPullProgressConsumer sets the consumer of the progress of the pull of the image of a container,
+// instead of logging it
+func WithPullProgressConsumer(consumer PullProgressConsumer) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.PullProgressConsumer = consumer
+	}
+}
+
 // Executable represents an executable command to be sent to a container, including options,
 // as part of the different lifecycle hooks.
 type Executable interface {
@@ -195,6 +218,19 @@ func WithWaitStrategy(strategies ...wait.Strategy) CustomizeRequestOption {
 	return WithWaitStrategyAndDeadline(60*time.Second, strategies...)
 }
 
+// WithAdditionalWaitStrategy adds the wait strategies to the ones already defined for a container,
+// which must be waited for too. It must be applied after WithWaitStrategy, which replaces them.
+func WithAdditionalWaitStrategy(strategies ...wait.Strategy) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		if req.WaitingFor == nil {
+			req.WaitingFor = wait.ForAll(strategies...)
+			return
+		}
+
+		req.WaitingFor = wait.ForAll(append([]wait.Strategy{req.WaitingFor}, strategies...)...)
+	}
+}
+
 // WithWaitStrategyAndDeadline sets the wait strategy for a container, including deadline
 func WithWaitStrategyAndDeadline(deadline time.Duration, strategies ...wait.Strategy) CustomizeRequestOption {
 	return func(req *GenericContainerRequest) {
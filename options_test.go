@@ -97,3 +97,16 @@ func TestWithStartupCommand(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "/tmp/.testcontainers\n", string(content))
 }
+
+func TestWithAdditionalWaitStrategy(t *testing.T) {
+	first := wait.ForLog("ready")
+	second := wait.ForListeningPort("8080/tcp")
+
+	req := testcontainers.GenericContainerRequest{}
+
+	testcontainers.WithAdditionalWaitStrategy(first)(&req)
+	assert.Equal(t, wait.ForAll(first), req.WaitingFor)
+
+	testcontainers.WithAdditionalWaitStrategy(second)(&req)
+	assert.Equal(t, wait.ForAll(wait.ForAll(first), second), req.WaitingFor)
+}
@@ -3,7 +3,10 @@ package testcontainers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
+
+	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 const (
@@ -31,87 +34,233 @@ func (gpe ParallelContainersError) Error() string {
 	return fmt.Sprintf("%v", gpe.Errors)
 }
 
-func parallelContainersRunner(
-	ctx context.Context,
-	requests <-chan GenericContainerRequest,
-	errors chan<- ParallelContainersRequestError,
-	containers chan<- Container,
-	wg *sync.WaitGroup,
-) {
-	for req := range requests {
-		c, err := GenericContainer(ctx, req)
-		if err != nil {
-			errors <- ParallelContainersRequestError{
-				Request: req,
-				Error:   err,
+// ContainerDependency declares that a container of a ParallelContainerRequest can only be created
+// once another container of the same request, identified by its name, has been created
+type ContainerDependency struct {
+	// Name is the name of the container request the container depends on
+	Name string
+	// WaitingFor is checked against the dependency before creating the container, in addition
+	// to the wait strategy of the dependency itself. It's optional.
+	WaitingFor wait.Strategy
+}
+
+// DependsOn declares a dependency on the container request with the given name, with an
+// optional readiness condition checked against the dependency
+func DependsOn(name string, waitingFor ...wait.Strategy) ContainerDependency {
+	dep := ContainerDependency{Name: name}
+	if len(waitingFor) > 0 {
+		dep.WaitingFor = waitingFor[0]
+	}
+	return dep
+}
+
+// DependencyCycleError is returned by ParallelContainers when the dependencies
+// of the requests form a cycle, which is described by the names of the requests
+type DependencyCycleError struct {
+	Cycle []string
+}
+
+func (e DependencyCycleError) Error() string {
+	return "dependency cycle: " + strings.Join(e.Cycle, " -> ")
+}
+
+// parallelTask is a request of a ParallelContainerRequest, with its position in the dependency graph
+type parallelTask struct {
+	req GenericContainerRequest
+	// dependencies are the indexes of the tasks this task depends on
+	dependencies []int
+	// dependents are the indexes of the tasks depending on this task
+	dependents []int
+	// pending is the number of dependencies which are not created yet
+	pending   int
+	done      bool
+	container Container
+}
+
+// newParallelTasks builds the dependency graph of the requests
+func newParallelTasks(reqs ParallelContainerRequest) ([]*parallelTask, error) {
+	tasks := make([]*parallelTask, len(reqs))
+	byName := make(map[string][]int)
+	for i, req := range reqs {
+		tasks[i] = &parallelTask{req: req}
+		if req.Name != "" {
+			byName[req.Name] = append(byName[req.Name], i)
+		}
+	}
+
+	for i, task := range tasks {
+		for _, dep := range task.req.DependsOn {
+			indexes := byName[dep.Name]
+			switch len(indexes) {
+			case 0:
+				return nil, fmt.Errorf("container %q depends on unknown container %q", task.req.Name, dep.Name)
+			case 1:
+				task.dependencies = append(task.dependencies, indexes[0])
+				tasks[indexes[0]].dependents = append(tasks[indexes[0]].dependents, i)
+			default:
+				return nil, fmt.Errorf("container %q depends on %q, which is the name of several requests", task.req.Name, dep.Name)
+			}
+		}
+		task.pending = len(task.dependencies)
+	}
+
+	if cycle := findDependencyCycle(tasks); cycle != nil {
+		return nil, DependencyCycleError{Cycle: cycle}
+	}
+
+	return tasks, nil
+}
+
+// findDependencyCycle returns the names of the requests forming a cycle, if any,
+// using a depth-first search which visits the requests in order
+func findDependencyCycle(tasks []*parallelTask) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make([]int, len(tasks))
+	var stack []int
+
+	var visit func(i int) []string
+	visit = func(i int) []string {
+		state[i] = visiting
+		stack = append(stack, i)
+
+		for _, dep := range tasks[i].dependencies {
+			switch state[dep] {
+			case visiting:
+				var cycle []string
+				for j := len(stack) - 1; j >= 0; j-- {
+					if stack[j] == dep {
+						for _, k := range stack[j:] {
+							cycle = append(cycle, tasks[k].req.Name)
+						}
+						break
+					}
+				}
+				return append(cycle, tasks[dep].req.Name)
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[i] = visited
+		return nil
+	}
+
+	for i := range tasks {
+		if state[i] == unvisited {
+			if cycle := visit(i); cycle != nil {
+				return cycle
 			}
-			continue
 		}
-		containers <- c
 	}
-	wg.Done()
+
+	return nil
 }
 
-// ParallelContainers creates a generic containers with parameters and run it in parallel mode
+// ParallelContainers creates a generic containers with parameters and run it in parallel mode.
+// Requests declaring dependencies with DependsOn are created once their dependencies are created
+// and meet the readiness conditions, while independent requests are created in parallel.
 func ParallelContainers(ctx context.Context, reqs ParallelContainerRequest, opt ParallelContainersOptions) ([]Container, error) {
+	return parallelContainers(ctx, reqs, opt, GenericContainer)
+}
+
+// parallelContainers runs the requests in topological order, creating each container with the create function
+func parallelContainers(
+	ctx context.Context,
+	reqs ParallelContainerRequest,
+	opt ParallelContainersOptions,
+	create func(context.Context, GenericContainerRequest) (Container, error),
+) ([]Container, error) {
 	if opt.WorkersCount == 0 {
 		opt.WorkersCount = defaultWorkersCount
 	}
 
-	tasksChanSize := opt.WorkersCount
-	if tasksChanSize > len(reqs) {
-		tasksChanSize = len(reqs)
+	tasks, err := newParallelTasks(reqs)
+	if err != nil {
+		return nil, err
 	}
 
-	tasksChan := make(chan GenericContainerRequest, tasksChanSize)
-	errsChan := make(chan ParallelContainersRequestError)
-	resChan := make(chan Container)
-	waitRes := make(chan struct{})
-
 	containers := make([]Container, 0)
 	errors := make([]ParallelContainersRequestError, 0)
 
-	wg := sync.WaitGroup{}
-	wg.Add(tasksChanSize)
+	if len(tasks) == 0 {
+		return containers, nil
+	}
 
-	// run workers
-	for i := 0; i < tasksChanSize; i++ {
-		go parallelContainersRunner(ctx, tasksChan, errsChan, resChan, &wg)
+	workersCount := opt.WorkersCount
+	if workersCount > len(tasks) {
+		workersCount = len(tasks)
+	}
+
+	// the channel can hold every task, so that scheduling never blocks
+	tasksChan := make(chan int, len(tasks))
+	for i, task := range tasks {
+		if task.pending == 0 {
+			tasksChan <- i
+		}
 	}
 
-	go func() {
-		for {
-			select {
-			case c, ok := <-resChan:
-				if !ok {
-					resChan = nil
-				} else {
-					containers = append(containers, c)
+	var mtx sync.Mutex
+	remaining := len(tasks)
+
+	// finish records the result of a task, and schedules the dependents ready to be created.
+	// It must be called with the lock held.
+	var finish func(i int, c Container, err error)
+	finish = func(i int, c Container, err error) {
+		task := tasks[i]
+		task.done = true
+		remaining--
+
+		if err != nil {
+			errors = append(errors, ParallelContainersRequestError{Request: task.req, Error: err})
+			// the dependents will never be created
+			for _, d := range task.dependents {
+				if !tasks[d].done {
+					finish(d, nil, fmt.Errorf("dependency %q failed: %w", task.req.Name, err))
 				}
-			case e, ok := <-errsChan:
-				if !ok {
-					errsChan = nil
-				} else {
-					errors = append(errors, e)
+			}
+		} else {
+			task.container = c
+			containers = append(containers, c)
+			for _, d := range task.dependents {
+				tasks[d].pending--
+				if tasks[d].pending == 0 && !tasks[d].done {
+					tasksChan <- d
 				}
 			}
+		}
 
-			if resChan == nil && errsChan == nil {
-				waitRes <- struct{}{}
-				break
-			}
+		if remaining == 0 {
+			close(tasksChan)
 		}
-	}()
+	}
 
-	for _, req := range reqs {
-		tasksChan <- req
+	wg := sync.WaitGroup{}
+	wg.Add(workersCount)
+
+	// run workers
+	for w := 0; w < workersCount; w++ {
+		go func() {
+			defer wg.Done()
+
+			for i := range tasksChan {
+				c, err := runParallelTask(ctx, tasks, i, create)
+
+				mtx.Lock()
+				finish(i, c, err)
+				mtx.Unlock()
+			}
+		}()
 	}
-	close(tasksChan)
-	wg.Wait()
-	close(resChan)
-	close(errsChan)
 
-	<-waitRes
+	wg.Wait()
 
 	if len(errors) != 0 {
 		return containers, ParallelContainersError{Errors: errors}
@@ -119,3 +268,26 @@ func ParallelContainers(ctx context.Context, reqs ParallelContainerRequest, opt
 
 	return containers, nil
 }
+
+// runParallelTask waits for the readiness conditions of the dependencies of the task, and creates its container.
+// The dependencies are already created, so their containers can be read without lock.
+func runParallelTask(
+	ctx context.Context,
+	tasks []*parallelTask,
+	i int,
+	create func(context.Context, GenericContainerRequest) (Container, error),
+) (Container, error) {
+	task := tasks[i]
+
+	for j, dep := range task.req.DependsOn {
+		if dep.WaitingFor == nil {
+			continue
+		}
+
+		if err := dep.WaitingFor.WaitUntilReady(ctx, tasks[task.dependencies[j]].container); err != nil {
+			return nil, fmt.Errorf("wait for dependency %q: %w", dep.Name, err)
+		}
+	}
+
+	return create(ctx, task.req)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -165,3 +167,145 @@ func TestParallelContainersWithReuse(t *testing.T) {
 	// Container is reused, only terminate first container
 	terminateContainerOnEnd(t, ctx, res[0])
 }
+
+func TestParallelContainersDependencies(t *testing.T) {
+	request := func(name string, deps ...ContainerDependency) GenericContainerRequest {
+		return GenericContainerRequest{
+			ContainerRequest: ContainerRequest{Name: name},
+			DependsOn:        deps,
+		}
+	}
+
+	t.Run("creates containers in topological order", func(t *testing.T) {
+		var mtx sync.Mutex
+		var created []string
+		create := func(_ context.Context, req GenericContainerRequest) (Container, error) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			created = append(created, req.Name)
+			return &DockerContainer{ID: req.Name}, nil
+		}
+
+		var checked string
+		reqs := ParallelContainerRequest{
+			request("app", DependsOn("kafka", wait.ForNop(func(_ context.Context, target wait.StrategyTarget) error {
+				checked = target.(*DockerContainer).ID
+				return nil
+			})), DependsOn("db")),
+			request("kafka", DependsOn("zookeeper")),
+			request("zookeeper"),
+			request("db"),
+		}
+
+		res, err := parallelContainers(context.Background(), reqs, ParallelContainersOptions{}, create)
+		require.NoError(t, err)
+		require.Len(t, res, 4)
+		require.Equal(t, "kafka", checked)
+
+		index := func(name string) int {
+			for i, n := range created {
+				if n == name {
+					return i
+				}
+			}
+			return -1
+		}
+		require.Less(t, index("zookeeper"), index("kafka"))
+		require.Less(t, index("kafka"), index("app"))
+		require.Less(t, index("db"), index("app"))
+	})
+
+	t.Run("creates independent containers in parallel", func(t *testing.T) {
+		var running, maxRunning int32
+		create := func(_ context.Context, req GenericContainerRequest) (Container, error) {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(50 * time.Millisecond)
+			return &DockerContainer{ID: req.Name}, nil
+		}
+
+		reqs := ParallelContainerRequest{request("a"), request("b"), request("c"), request("d", DependsOn("a"))}
+
+		res, err := parallelContainers(context.Background(), reqs, ParallelContainersOptions{WorkersCount: 2}, create)
+		require.NoError(t, err)
+		require.Len(t, res, 4)
+		require.Equal(t, int32(2), maxRunning)
+	})
+
+	t.Run("skips the dependents of a failed container", func(t *testing.T) {
+		errCreate := errors.New("create failed")
+		create := func(_ context.Context, req GenericContainerRequest) (Container, error) {
+			if req.Name == "db" {
+				return nil, errCreate
+			}
+			return &DockerContainer{ID: req.Name}, nil
+		}
+
+		reqs := ParallelContainerRequest{
+			request("app", DependsOn("migrations")),
+			request("migrations", DependsOn("db")),
+			request("db"),
+			request("cache"),
+		}
+
+		res, err := parallelContainers(context.Background(), reqs, ParallelContainersOptions{}, create)
+		require.Len(t, res, 1)
+
+		var e ParallelContainersError
+		require.ErrorAs(t, err, &e)
+		require.Len(t, e.Errors, 3)
+		for _, re := range e.Errors {
+			require.ErrorIs(t, re.Error, errCreate)
+		}
+	})
+
+	t.Run("fails when the readiness condition is not met", func(t *testing.T) {
+		create := func(_ context.Context, req GenericContainerRequest) (Container, error) {
+			return &DockerContainer{ID: req.Name}, nil
+		}
+
+		reqs := ParallelContainerRequest{
+			request("app", DependsOn("db", wait.ForNop(func(_ context.Context, _ wait.StrategyTarget) error {
+				return errors.New("not ready")
+			}))),
+			request("db"),
+		}
+
+		res, err := parallelContainers(context.Background(), reqs, ParallelContainersOptions{}, create)
+		require.Len(t, res, 1)
+
+		var e ParallelContainersError
+		require.ErrorAs(t, err, &e)
+		require.Len(t, e.Errors, 1)
+		require.Equal(t, "app", e.Errors[0].Request.Name)
+		require.EqualError(t, e.Errors[0].Error, `wait for dependency "db": not ready`)
+	})
+
+	t.Run("reports cycles", func(t *testing.T) {
+		reqs := ParallelContainerRequest{
+			request("db"),
+			request("app", DependsOn("kafka")),
+			request("kafka", DependsOn("zookeeper")),
+			request("zookeeper", DependsOn("app")),
+		}
+
+		_, err := ParallelContainers(context.Background(), reqs, ParallelContainersOptions{})
+
+		var e DependencyCycleError
+		require.ErrorAs(t, err, &e)
+		require.EqualError(t, err, "dependency cycle: app -> kafka -> zookeeper -> app")
+	})
+
+	t.Run("reports unknown dependencies", func(t *testing.T) {
+		reqs := ParallelContainerRequest{request("app", DependsOn("kafka"))}
+
+		_, err := ParallelContainers(context.Background(), reqs, ParallelContainersOptions{})
+		require.EqualError(t, err, `container "app" depends on unknown container "kafka"`)
+	})
+}
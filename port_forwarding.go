@@ -0,0 +1,208 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// HostInternal is the hostname from which the containers can reach the ports of the host
+	// exposed with ContainerRequest.HostAccessPorts
+	HostInternal = "host.testcontainers.internal"
+
+	sshdImage = "testcontainers/sshd:1.1.0"
+	sshdPort  = "22/tcp"
+	sshdUser  = "root"
+)
+
+// ExposeHostPorts exposes the given ports of the host to the container, which can reach them
+// on host.testcontainers.internal, e.g. to call back a server started by the tests
+func ExposeHostPorts(ports ...int) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.HostAccessPorts = append(req.HostAccessPorts, ports...)
+	}
+}
+
+// sshdContainer is a sidecar forwarding the ports of the host to the containers through SSH
+// remote port forwarding, which works for local and remote Docker daemons alike
+type sshdContainer struct {
+	Container
+	client    *ssh.Client
+	listeners []net.Listener
+	wg        sync.WaitGroup
+}
+
+// exposeHostPorts starts a sidecar forwarding the host access ports of the request, returning the hooks
+// resolving host.testcontainers.internal to the sidecar in the container, and terminating the sidecar with it
+func exposeHostPorts(ctx context.Context, req *ContainerRequest, hostConfig *container.HostConfig) (*sshdContainer, ContainerLifecycleHooks, error) {
+	sshd, err := newSshdContainer(ctx, req.Networks)
+	if err != nil {
+		return nil, ContainerLifecycleHooks{}, err
+	}
+
+	for _, port := range req.HostAccessPorts {
+		if err := sshd.exposeHostPort(port); err != nil {
+			return nil, ContainerLifecycleHooks{}, errors.Join(err, sshd.Terminate(ctx))
+		}
+	}
+
+	ip, err := sshd.ipAddress(ctx, req.Networks)
+	if err != nil {
+		return nil, ContainerLifecycleHooks{}, errors.Join(err, sshd.Terminate(ctx))
+	}
+
+	hooks := ContainerLifecycleHooks{
+		PreCreates: []ContainerRequestHook{
+			// the default pre-create hook has already set the extra hosts of the request
+			func(ctx context.Context, req ContainerRequest) error {
+				hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, HostInternal+":"+ip)
+				return nil
+			},
+		},
+		PostTerminates: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				return sshd.Terminate(ctx)
+			},
+		},
+	}
+
+	return sshd, hooks, nil
+}
+
+// newSshdContainer starts the SSH sidecar on the given networks, or on the default one, and connects to it
+func newSshdContainer(ctx context.Context, networks []string) (*sshdContainer, error) {
+	password := uuid.NewString()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        sshdImage,
+			ExposedPorts: []string{sshdPort},
+			Env:          map[string]string{"PASSWORD": password},
+			Networks:     networks,
+			// GatewayPorts makes the forwarded ports listen on all the interfaces, not only the loopback
+			Entrypoint: []string{"sh", "-c"},
+			Cmd: []string{
+				`echo "root:$PASSWORD" | chpasswd && /usr/sbin/sshd -D -o PermitRootLogin=yes -o AddressFamily=inet -o GatewayPorts=yes -o AllowTcpForwarding=yes`,
+			},
+			WaitingFor: wait.ForListeningPort(sshdPort),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("start sshd container: %w", err)
+	}
+
+	sshd := &sshdContainer{Container: c}
+
+	endpoint, err := c.PortEndpoint(ctx, sshdPort, "")
+	if err != nil {
+		return nil, errors.Join(err, c.Terminate(ctx))
+	}
+
+	sshd.client, err = ssh.Dial("tcp", endpoint, &ssh.ClientConfig{
+		User: sshdUser,
+		Auth: []ssh.AuthMethod{ssh.Password(password)},
+		// the sidecar generates its host keys when it's built
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         30 * time.Second,
+	})
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("connect to sshd container: %w", err), c.Terminate(ctx))
+	}
+
+	return sshd, nil
+}
+
+// ipAddress returns the address of the sidecar on the first of the given networks, or on the default one
+func (s *sshdContainer) ipAddress(ctx context.Context, networks []string) (string, error) {
+	if len(networks) == 0 {
+		return s.ContainerIP(ctx)
+	}
+
+	dc, ok := s.Container.(*DockerContainer)
+	if !ok {
+		return "", fmt.Errorf("unexpected container type %T", s.Container)
+	}
+
+	inspect, err := dc.inspectContainer(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	settings, ok := inspect.NetworkSettings.Networks[networks[0]]
+	if !ok {
+		return "", fmt.Errorf("sshd container is not attached to network %s", networks[0])
+	}
+	return settings.IPAddress, nil
+}
+
+// exposeHostPort listens on the port in the sidecar, forwarding the connections to the same port of the host
+func (s *sshdContainer) exposeHostPort(port int) error {
+	listener, err := s.client.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", port))
+	if err != nil {
+		return fmt.Errorf("forward host port %d: %w", port, err)
+	}
+	s.listeners = append(s.listeners, listener)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		for {
+			remote, err := listener.Accept()
+			if err != nil {
+				// the listener is closed
+				return
+			}
+
+			go forward(remote, fmt.Sprintf("localhost:%d", port))
+		}
+	}()
+
+	return nil
+}
+
+// forward copies the data between the remote connection and the local address, until one of them is closed
+func forward(remote net.Conn, address string) {
+	defer remote.Close()
+
+	local, err := net.Dial("tcp", address)
+	if err != nil {
+		return
+	}
+	defer local.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(local, remote)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(remote, local)
+		done <- struct{}{}
+	}()
+
+	<-done
+}
+
+// Terminate stops forwarding the ports and terminates the sidecar
+func (s *sshdContainer) Terminate(ctx context.Context) error {
+	for _, l := range s.listeners {
+		_ = l.Close()
+	}
+	_ = s.client.Close()
+	s.wg.Wait()
+
+	return s.Container.Terminate(ctx)
+}
@@ -0,0 +1,91 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestExposeHostPorts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("hello from the host"))
+	}))
+	t.Cleanup(server.Close)
+
+	port := server.Listener.Addr().(*net.TCPAddr).Port
+
+	tests := []struct {
+		name     string
+		networks func(t *testing.T) []string
+	}{
+		{
+			name:     "default network",
+			networks: func(t *testing.T) []string { return nil },
+		},
+		{
+			name: "custom network",
+			networks: func(t *testing.T) []string {
+				name := "test-expose-host-ports"
+				nw, err := GenericNetwork(context.Background(), GenericNetworkRequest{
+					NetworkRequest: NetworkRequest{Name: name},
+				})
+				require.NoError(t, err)
+				t.Cleanup(func() {
+					require.NoError(t, nw.Remove(context.Background()))
+				})
+				return []string{name}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			req := GenericContainerRequest{
+				ContainerRequest: ContainerRequest{
+					Image:      nginxAlpineImage,
+					Networks:   tt.networks(t),
+					Cmd:        []string{"wget", "-q", "-O", "-", fmt.Sprintf("http://%s:%d", HostInternal, port)},
+					WaitingFor: wait.ForExit(),
+				},
+				Started: true,
+			}
+			ExposeHostPorts(port)(&req)
+
+			c, err := GenericContainer(ctx, req)
+			require.NoError(t, err)
+			terminateContainerOnEnd(t, ctx, c)
+
+			state, err := c.State(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 0, state.ExitCode)
+
+			logs, err := c.Logs(ctx)
+			require.NoError(t, err)
+			defer logs.Close()
+
+			b, err := io.ReadAll(logs)
+			require.NoError(t, err)
+			assert.Contains(t, string(b), "hello from the host")
+		})
+	}
+}
+
+func TestExposeHostPortsAccumulates(t *testing.T) {
+	req := GenericContainerRequest{}
+
+	ExposeHostPorts(8080)(&req)
+	ExposeHostPorts(8081, 8082)(&req)
+
+	assert.Equal(t, []int{8080, 8081, 8082}, req.HostAccessPorts)
+}
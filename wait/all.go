@@ -16,6 +16,8 @@ type MultiStrategy struct {
 	// all Strategies should have a startupTimeout to avoid waiting infinitely
 	timeout  *time.Duration
 	deadline *time.Duration
+	// newBackoff creates the backoff of each inner strategy which does not define its own
+	newBackoff func() Backoff
 
 	// additional properties
 	Strategies []Strategy
@@ -27,6 +29,14 @@ func (ms *MultiStrategy) WithStartupTimeoutDefault(timeout time.Duration) *Multi
 	return ms
 }
 
+// WithBackoffDefault sets the default backoff for all inner wait strategies,
+// which is used instead of their poll interval unless they define their own backoff.
+// Backoffs hold state, so the function is called to create a new backoff for each strategy.
+func (ms *MultiStrategy) WithBackoffDefault(newBackoff func() Backoff) *MultiStrategy {
+	ms.newBackoff = newBackoff
+	return ms
+}
+
 // WithStartupTimeout sets a time.Duration which limits all wait strategies
 //
 // Deprecated: use WithDeadline
@@ -61,6 +71,10 @@ func (ms *MultiStrategy) WaitUntilReady(ctx context.Context, target StrategyTarg
 		return fmt.Errorf("no wait strategy supplied")
 	}
 
+	if ms.newBackoff != nil {
+		ctx = withDefaultBackoff(ctx, ms.newBackoff)
+	}
+
 	for _, strategy := range ms.Strategies {
 		strategyCtx := ctx
 
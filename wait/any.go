@@ -0,0 +1,101 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*AnyStrategy)(nil)
+	_ StrategyTimeout = (*AnyStrategy)(nil)
+)
+
+// AnyStrategy holds a list of wait strategies which run concurrently.
+// The container is ready as soon as one of them succeeds, and the others are cancelled.
+type AnyStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout  *time.Duration
+	deadline *time.Duration
+
+	// additional properties
+	Strategies []Strategy
+}
+
+// WithStartupTimeoutDefault sets the default timeout for all inner wait strategies
+func (as *AnyStrategy) WithStartupTimeoutDefault(timeout time.Duration) *AnyStrategy {
+	as.timeout = &timeout
+	return as
+}
+
+// WithDeadline sets a time.Duration which limits all wait strategies
+func (as *AnyStrategy) WithDeadline(deadline time.Duration) *AnyStrategy {
+	as.deadline = &deadline
+	return as
+}
+
+// ForAny is the default construction for the fluid interface.
+//
+// For Example:
+//
+//	wait.ForAny(
+//		wait.ForLog("ready for connections"),
+//		wait.ForHTTP("/health"),
+//	)
+func ForAny(strategies ...Strategy) *AnyStrategy {
+	return &AnyStrategy{
+		Strategies: strategies,
+	}
+}
+
+func (as *AnyStrategy) Timeout() *time.Duration {
+	return as.timeout
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (as *AnyStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	var cancel context.CancelFunc
+	if as.deadline != nil {
+		ctx, cancel = context.WithTimeout(ctx, *as.deadline)
+		defer cancel()
+	}
+
+	if len(as.Strategies) == 0 {
+		return fmt.Errorf("no wait strategy supplied")
+	}
+
+	// the remaining strategies are cancelled once one of them succeeds
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan error, len(as.Strategies))
+	for _, strategy := range as.Strategies {
+		go func(strategy Strategy) {
+			strategyCtx := ctx
+
+			// Set default Timeout when strategy implements StrategyTimeout
+			if st, ok := strategy.(StrategyTimeout); ok {
+				if as.Timeout() != nil && st.Timeout() == nil {
+					var cancel context.CancelFunc
+					strategyCtx, cancel = context.WithTimeout(ctx, *as.Timeout())
+					defer cancel()
+				}
+			}
+
+			results <- strategy.WaitUntilReady(strategyCtx, target)
+		}(strategy)
+	}
+
+	errs := make([]error, 0, len(as.Strategies))
+	for range as.Strategies {
+		err := <-results
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+
+	return fmt.Errorf("no wait strategy succeeded: %w", errors.Join(errs...))
+}
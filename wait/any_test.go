@@ -0,0 +1,116 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnyStrategy_WaitUntilReady(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns error when no WaitStrategies are passed", func(t *testing.T) {
+		require.Error(t, ForAny().WaitUntilReady(context.Background(), NopStrategyTarget{}))
+	})
+
+	t.Run("succeeds as soon as one strategy succeeds", func(t *testing.T) {
+		cancelled := make(chan struct{})
+
+		ws := ForAny(
+			ForNop(func(ctx context.Context, _ StrategyTarget) error {
+				<-ctx.Done()
+				close(cancelled)
+				return ctx.Err()
+			}),
+			ForNop(func(_ context.Context, _ StrategyTarget) error {
+				return nil
+			}),
+		)
+
+		require.NoError(t, ws.WaitUntilReady(context.Background(), NopStrategyTarget{}))
+
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Fatal("expected the remaining strategy to be cancelled")
+		}
+	})
+
+	t.Run("returns all errors when every strategy fails", func(t *testing.T) {
+		errFirst := errors.New("first failure")
+		errSecond := errors.New("second failure")
+
+		ws := ForAny(
+			ForNop(func(_ context.Context, _ StrategyTarget) error { return errFirst }),
+			ForNop(func(_ context.Context, _ StrategyTarget) error { return errSecond }),
+		)
+
+		err := ws.WaitUntilReady(context.Background(), NopStrategyTarget{})
+		require.ErrorIs(t, err, errFirst)
+		require.ErrorIs(t, err, errSecond)
+	})
+
+	t.Run("WithDeadline sets context Deadline for WaitStrategy", func(t *testing.T) {
+		ws := ForAny(
+			ForNop(func(ctx context.Context, _ StrategyTarget) error {
+				_, set := ctx.Deadline()
+				assert.True(t, set)
+				return nil
+			}),
+		).WithDeadline(time.Second)
+
+		require.NoError(t, ws.WaitUntilReady(context.Background(), NopStrategyTarget{}))
+	})
+}
+
+// TestAnyStrategy_backoffDefault runs the strategies of an AnyStrategy concurrently under
+// the default backoff of a MultiStrategy, to be run with -race
+func TestAnyStrategy_backoffDefault(t *testing.T) {
+	var mu sync.Mutex
+	var backoffs []*countingBackoff
+	newBackoff := func() Backoff {
+		mu.Lock()
+		defer mu.Unlock()
+
+		b := &countingBackoff{interval: 5 * time.Millisecond}
+		backoffs = append(backoffs, b)
+		return b
+	}
+
+	var polls int32
+	exitingTarget := &MockStrategyTarget{
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: atomic.AddInt32(&polls, 1) < 10}, nil
+		},
+	}
+	runningTarget := &MockStrategyTarget{
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+	}
+
+	ms := ForAll(
+		ForAny(
+			ForNop(func(ctx context.Context, _ StrategyTarget) error {
+				return ForExit().WaitUntilReady(ctx, exitingTarget)
+			}),
+			ForNop(func(ctx context.Context, _ StrategyTarget) error {
+				return ForExit().WaitUntilReady(ctx, runningTarget)
+			}),
+		),
+	).WithBackoffDefault(newBackoff).WithDeadline(5 * time.Second)
+
+	require.NoError(t, ms.WaitUntilReady(context.Background(), NopStrategyTarget{}))
+
+	mu.Lock()
+	defer mu.Unlock()
+	// the backoffs are not inspected, as the cancelled strategy may still be using its own
+	assert.Len(t, backoffs, 2)
+}
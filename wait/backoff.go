@@ -0,0 +1,114 @@
+package wait
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// Backoff defines the intervals between the attempts of a wait strategy.
+// It is compatible with the backoff.BackOff interface of github.com/cenkalti/backoff,
+// so any of its implementations can be used.
+//
+// Backoffs are stateful: they are reset when a strategy starts waiting, so the same
+// instance must not be used by strategies waiting concurrently.
+type Backoff interface {
+	// NextBackOff returns the duration to wait before the next attempt.
+	// Strategies stop waiting only when their timeout is reached, so a negative
+	// duration, such as backoff.Stop, makes them fall back to the default poll interval.
+	NextBackOff() time.Duration
+	// Reset restores the backoff to its initial state.
+	Reset()
+}
+
+// NewConstantBackoff returns a Backoff which always waits the same interval,
+// which is the behaviour of the poll interval of the strategies.
+func NewConstantBackoff(interval time.Duration) Backoff {
+	return backoff.NewConstantBackOff(interval)
+}
+
+// NewExponentialBackoff returns a Backoff which starts waiting the initial interval,
+// doubling it after each attempt up to the max interval.
+func NewExponentialBackoff(initial time.Duration, maxInterval time.Duration) Backoff {
+	b := &backoff.ExponentialBackOff{
+		InitialInterval:     initial,
+		RandomizationFactor: 0,
+		Multiplier:          2,
+		MaxInterval:         maxInterval,
+		// the strategies are limited by their own timeout
+		MaxElapsedTime: 0,
+		Stop:           backoff.Stop,
+		Clock:          backoff.SystemClock,
+	}
+	b.Reset()
+	return b
+}
+
+// NewJitteredBackoff returns a Backoff which randomizes the intervals of the given backoff
+// by up to the given factor, e.g. a factor of 0.5 turns an interval of 1 second into a random
+// interval between 0.5 and 1.5 seconds. It avoids several containers being polled in lockstep.
+func NewJitteredBackoff(b Backoff, factor float64) Backoff {
+	return &jitteredBackoff{
+		backoff: b,
+		factor:  factor,
+	}
+}
+
+type jitteredBackoff struct {
+	backoff Backoff
+	factor  float64
+}
+
+func (b *jitteredBackoff) NextBackOff() time.Duration {
+	next := b.backoff.NextBackOff()
+	if next < 0 {
+		return next
+	}
+
+	delta := b.factor * float64(next)
+	lower := float64(next) - delta
+
+	return time.Duration(lower + rand.Float64()*2*delta)
+}
+
+func (b *jitteredBackoff) Reset() {
+	b.backoff.Reset()
+}
+
+// defaultBackoffKey is the context key for the function creating the default backoff set by a MultiStrategy
+type defaultBackoffKey struct{}
+
+// withDefaultBackoff returns a context holding the function which creates the backoff to be used
+// by the strategies which do not define their own. Each strategy gets its own backoff, as the
+// strategies receiving the context may wait concurrently, e.g. inside an AnyStrategy.
+func withDefaultBackoff(ctx context.Context, newBackoff func() Backoff) context.Context {
+	return context.WithValue(ctx, defaultBackoffKey{}, newBackoff)
+}
+
+// pollBackoff returns the reset backoff to be used by a strategy: its own backoff if set,
+// a new default backoff of the MultiStrategy running it if any, or a constant backoff using
+// its poll interval otherwise.
+func pollBackoff(ctx context.Context, b Backoff, pollInterval time.Duration) Backoff {
+	if b == nil {
+		if newBackoff, ok := ctx.Value(defaultBackoffKey{}).(func() Backoff); ok {
+			b = newBackoff()
+		}
+	}
+	if b == nil {
+		b = NewConstantBackoff(pollInterval)
+	}
+
+	b.Reset()
+	return b
+}
+
+// nextInterval returns the duration to wait before the next attempt
+func nextInterval(b Backoff) time.Duration {
+	next := b.NextBackOff()
+	if next < 0 {
+		return defaultPollInterval()
+	}
+	return next
+}
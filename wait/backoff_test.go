@@ -0,0 +1,136 @@
+package wait
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingBackoff is a constant backoff which records how it was used
+type countingBackoff struct {
+	interval time.Duration
+	calls    int
+	resets   int
+}
+
+func (b *countingBackoff) NextBackOff() time.Duration {
+	b.calls++
+	return b.interval
+}
+
+func (b *countingBackoff) Reset() {
+	b.resets++
+}
+
+func TestConstantBackoff(t *testing.T) {
+	b := NewConstantBackoff(200 * time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 200*time.Millisecond, b.NextBackOff())
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := NewExponentialBackoff(100*time.Millisecond, time.Second)
+
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+	for _, e := range expected {
+		assert.Equal(t, e, b.NextBackOff())
+	}
+
+	b.Reset()
+	assert.Equal(t, 100*time.Millisecond, b.NextBackOff())
+}
+
+func TestJitteredBackoff(t *testing.T) {
+	b := NewJitteredBackoff(NewConstantBackoff(time.Second), 0.5)
+
+	for i := 0; i < 100; i++ {
+		next := b.NextBackOff()
+		assert.GreaterOrEqual(t, next, 500*time.Millisecond)
+		assert.LessOrEqual(t, next, 1500*time.Millisecond)
+	}
+}
+
+func TestNextIntervalFallsBackForStoppedBackoff(t *testing.T) {
+	assert.Equal(t, defaultPollInterval(), nextInterval(&backoff.StopBackOff{}))
+}
+
+func TestWaitWithBackoff(t *testing.T) {
+	var logsCalls int
+	target := &MockStrategyTarget{
+		LogsImpl: func(_ context.Context) (io.ReadCloser, error) {
+			logsCalls++
+			if logsCalls < 3 {
+				return io.NopCloser(strings.NewReader("starting")), nil
+			}
+			return io.NopCloser(strings.NewReader("ready")), nil
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+	}
+
+	b := &countingBackoff{interval: 10 * time.Millisecond}
+
+	wg := ForLog("ready").
+		WithBackoff(b).
+		WithPollInterval(time.Hour).
+		WithStartupTimeout(time.Second)
+
+	require.NoError(t, wg.WaitUntilReady(context.Background(), target))
+	assert.Equal(t, 1, b.resets)
+	assert.Equal(t, 2, b.calls)
+}
+
+func TestMultiStrategyBackoffDefault(t *testing.T) {
+	target := NopStrategyTarget{
+		ReaderCloser:   io.NopCloser(strings.NewReader("ready")),
+		ContainerState: types.ContainerState{Running: false},
+	}
+
+	var defaultBackoffs []*countingBackoff
+	newDefaultBackoff := func() Backoff {
+		b := &countingBackoff{interval: 10 * time.Millisecond}
+		defaultBackoffs = append(defaultBackoffs, b)
+		return b
+	}
+	ownBackoff := &countingBackoff{interval: 10 * time.Millisecond}
+
+	var running int
+	exitTarget := &MockStrategyTarget{
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			running++
+			return &types.ContainerState{Running: running < 3}, nil
+		},
+	}
+
+	ms := ForAll(
+		ForNop(func(ctx context.Context, _ StrategyTarget) error {
+			return ForExit().WithPollInterval(time.Hour).WaitUntilReady(ctx, exitTarget)
+		}),
+		ForNop(func(ctx context.Context, _ StrategyTarget) error {
+			return ForExit().WithBackoff(ownBackoff).WaitUntilReady(ctx, exitTarget)
+		}),
+	).WithBackoffDefault(newDefaultBackoff).WithDeadline(time.Second)
+
+	require.NoError(t, ms.WaitUntilReady(context.Background(), target))
+	require.Len(t, defaultBackoffs, 1)
+	assert.Equal(t, 2, defaultBackoffs[0].calls)
+	assert.Equal(t, 1, ownBackoff.resets)
+	assert.Equal(t, 0, ownBackoff.calls)
+}
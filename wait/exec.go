@@ -17,6 +17,8 @@ var (
 type ExecStrategy struct {
 	// all Strategies should have a startupTimeout to avoid waiting infinitely
 	timeout *time.Duration
+	// backoff defines the intervals between attempts, overriding the poll interval
+	backoff Backoff
 	cmd     []string
 
 	// additional properties
@@ -61,6 +63,13 @@ func (ws *ExecStrategy) WithPollInterval(pollInterval time.Duration) *ExecStrate
 	return ws
 }
 
+// WithBackoff can be used to replace the fixed poll interval by a backoff policy,
+// such as an exponential backoff for slow starting services
+func (ws *ExecStrategy) WithBackoff(b Backoff) *ExecStrategy {
+	ws.backoff = b
+	return ws
+}
+
 // ForExec is a convenience method to assign ExecStrategy
 func ForExec(cmd []string) *ExecStrategy {
 	return NewExecStrategy(cmd)
@@ -79,15 +88,19 @@ func (ws *ExecStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	poll := pollBackoff(ctx, ws.backoff, ws.PollInterval)
+
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-time.After(ws.PollInterval):
+		case <-time.After(nextInterval(poll)):
+			recordAttempt(ctx)
 			exitCode, resp, err := target.Exec(ctx, ws.cmd, tcexec.Multiplexed())
 			if err != nil {
 				return err
 			}
+			recordDetail(ctx, "exec %v exit code %d", ws.cmd, exitCode)
 			if !ws.ExitCodeMatcher(exitCode) {
 				continue
 			}
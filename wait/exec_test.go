@@ -93,6 +93,10 @@ func (st mockExecTarget) Exec(ctx context.Context, _ []string, options ...tcexec
 	return st.exitCode, reader, st.failure
 }
 
+func (st mockExecTarget) CopyFileFromContainer(_ context.Context, _ string) (io.ReadCloser, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (st mockExecTarget) State(_ context.Context) (*types.ContainerState, error) {
 	return nil, errors.New("not implemented")
 }
@@ -1,7 +1,9 @@
 package wait
 
 import (
+	"bufio"
 	"context"
+	"fmt"
 	"strings"
 	"time"
 )
@@ -16,16 +18,42 @@ var (
 type ExitStrategy struct {
 	// all Strategies should have a timeout to avoid waiting infinitely
 	timeout *time.Duration
+	// backoff defines the intervals between attempts, overriding the poll interval
+	backoff Backoff
 
 	// additional properties
 	PollInterval time.Duration
+	// ExitCodeMatcher checks the exit code of the container. If nil, any exit code is accepted.
+	ExitCodeMatcher func(exitCode int) bool
+	// OutputLines is the number of lines at the end of the container logs included in the
+	// error returned when the exit code does not match
+	OutputLines int
 }
 
+// defaultExitOutputLines is the default number of log lines reported when the exit code does not match
+const defaultExitOutputLines = 50
+
 // NewExitStrategy constructs with polling interval of 100 milliseconds without timeout by default
 func NewExitStrategy() *ExitStrategy {
 	return &ExitStrategy{
 		PollInterval: defaultPollInterval(),
+		OutputLines:  defaultExitOutputLines,
+	}
+}
+
+// ExitError is returned by the ExitStrategy when the exit code of the container does not match
+type ExitError struct {
+	ExitCode int
+	// Output holds the last lines of the container logs
+	Output []string
+}
+
+func (e *ExitError) Error() string {
+	if len(e.Output) == 0 {
+		return fmt.Sprintf("container exited with unexpected code %d", e.ExitCode)
 	}
+
+	return fmt.Sprintf("container exited with unexpected code %d, last %d log lines:\n%s", e.ExitCode, len(e.Output), strings.Join(e.Output, "\n"))
 }
 
 // fluent builders for each property
@@ -44,6 +72,33 @@ func (ws *ExitStrategy) WithPollInterval(pollInterval time.Duration) *ExitStrate
 	return ws
 }
 
+// WithBackoff can be used to replace the fixed poll interval by a backoff policy,
+// such as an exponential backoff for slow starting services
+func (ws *ExitStrategy) WithBackoff(b Backoff) *ExitStrategy {
+	ws.backoff = b
+	return ws
+}
+
+// WithExitCode can be used to require the container to exit with the given code
+func (ws *ExitStrategy) WithExitCode(exitCode int) *ExitStrategy {
+	return ws.WithExitCodeMatcher(func(code int) bool {
+		return code == exitCode
+	})
+}
+
+// WithExitCodeMatcher can be used to check the exit code of the container
+func (ws *ExitStrategy) WithExitCodeMatcher(exitCodeMatcher func(exitCode int) bool) *ExitStrategy {
+	ws.ExitCodeMatcher = exitCodeMatcher
+	return ws
+}
+
+// WithOutputLines can be used to override the default number of 50 log lines
+// reported when the exit code does not match
+func (ws *ExitStrategy) WithOutputLines(lines int) *ExitStrategy {
+	ws.OutputLines = lines
+	return ws
+}
+
 // ForExit is the default construction for the fluid interface.
 //
 // For Example:
@@ -67,24 +122,66 @@ func (ws *ExitStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		defer cancel()
 	}
 
+	poll := pollBackoff(ctx, ws.backoff, ws.PollInterval)
+
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
+			recordAttempt(ctx)
 			state, err := target.State(ctx)
 			if err != nil {
 				if !strings.Contains(err.Error(), "No such container") {
 					return err
+				} else if ws.ExitCodeMatcher != nil {
+					return fmt.Errorf("%w: the exit code cannot be checked", err)
 				} else {
 					return nil
 				}
 			}
 			if state.Running {
-				time.Sleep(ws.PollInterval)
+				time.Sleep(nextInterval(poll))
 				continue
 			}
+			recordDetail(ctx, "exit code %d", state.ExitCode)
+			if ws.ExitCodeMatcher != nil && !ws.ExitCodeMatcher(state.ExitCode) {
+				return &ExitError{
+					ExitCode: state.ExitCode,
+					Output:   lastLogLines(ctx, target, ws.OutputLines),
+				}
+			}
 			return nil
 		}
 	}
 }
+
+// lastLogLines returns the last lines of the target logs, or the error reading them
+func lastLogLines(ctx context.Context, target StrategyTarget, lines int) []string {
+	if lines <= 0 {
+		return nil
+	}
+
+	reader, err := target.Logs(ctx)
+	if err != nil {
+		return []string{fmt.Sprintf("failed accessing container logs: %v", err)}
+	}
+	if reader == nil {
+		return nil
+	}
+	defer reader.Close()
+
+	var output []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		output = append(output, scanner.Text())
+		if len(output) > lines {
+			output = output[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		output = append(output, fmt.Sprintf("failed reading container logs: %v", err))
+	}
+
+	return output
+}
@@ -2,12 +2,17 @@ package wait
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	tcexec "github.com/testcontainers/testcontainers-go/exec"
 )
@@ -36,6 +41,10 @@ func (st exitStrategyTarget) Exec(ctx context.Context, cmd []string, options ...
 	return 0, nil, nil
 }
 
+func (st exitStrategyTarget) CopyFileFromContainer(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	return nil, nil
+}
+
 func (st exitStrategyTarget) State(ctx context.Context) (*types.ContainerState, error) {
 	return &types.ContainerState{Running: st.isRunning}, nil
 }
@@ -50,3 +59,60 @@ func TestWaitForExit(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestWaitForExitWithExitCode(t *testing.T) {
+	target := &MockStrategyTarget{
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: false, ExitCode: 0}, nil
+		},
+	}
+
+	wg := ForExit().WithExitCode(0).WithExitTimeout(100 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.NoError(t, err)
+}
+
+func TestWaitForExitFailsWithUnexpectedExitCode(t *testing.T) {
+	var logs strings.Builder
+	for i := 1; i <= 10; i++ {
+		logs.WriteString(fmt.Sprintf("line %d\n", i))
+	}
+
+	target := &MockStrategyTarget{
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: false, ExitCode: 108}, nil
+		},
+		LogsImpl: func(_ context.Context) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(logs.String())), nil
+		},
+	}
+
+	wg := ForExit().
+		WithExitCodeMatcher(func(exitCode int) bool { return exitCode < 100 }).
+		WithOutputLines(3).
+		WithExitTimeout(100 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.Error(t, err)
+
+	var exitErr *ExitError
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, 108, exitErr.ExitCode)
+	assert.Equal(t, []string{"line 8", "line 9", "line 10"}, exitErr.Output)
+	assert.Equal(t, "container exited with unexpected code 108, last 3 log lines:\nline 8\nline 9\nline 10", err.Error())
+}
+
+func TestWaitForExitWithExitCodeFailsForRemovedContainer(t *testing.T) {
+	target := &MockStrategyTarget{
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return nil, errors.New("Error response from daemon: No such container: 1234")
+		},
+	}
+
+	err := ForExit().WaitUntilReady(context.Background(), target)
+	require.NoError(t, err)
+
+	err = ForExit().WithExitCode(0).WaitUntilReady(context.Background(), target)
+	require.Error(t, err)
+}
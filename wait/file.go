@@ -0,0 +1,157 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*FileStrategy)(nil)
+	_ StrategyTimeout = (*FileStrategy)(nil)
+)
+
+// FileStrategy will wait until a file exists in the container, optionally checking its content.
+// The file is read with StrategyTarget.CopyFileFromContainer, so no shell is needed in the container.
+type FileStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+	// backoff defines the intervals between attempts, overriding the poll interval
+	backoff Backoff
+
+	// additional properties
+	File string
+	// Matcher is called with the content of the file. If it returns an error,
+	// the file is read again after the poll interval.
+	Matcher      func(content io.Reader) error
+	PollInterval time.Duration
+}
+
+// NewFileStrategy constructs with polling interval of 100 milliseconds and startup timeout of 60 seconds by default
+func NewFileStrategy(file string) *FileStrategy {
+	return &FileStrategy{
+		File:         file,
+		PollInterval: defaultPollInterval(),
+	}
+}
+
+// fluent builders for each property
+// since go has neither covariance nor generics, the return type must be the type of the concrete implementation
+// this is true for all properties, even the "shared" ones like startupTimeout
+
+// ForFile is the default construction for the fluid interface.
+//
+// For Example:
+//
+//	wait.
+//		ForFile("/etc/rancher/k3s/k3s.yaml").
+//		WithPollInterval(1 * time.Second)
+func ForFile(file string) *FileStrategy {
+	return NewFileStrategy(file)
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (ws *FileStrategy) WithStartupTimeout(startupTimeout time.Duration) *FileStrategy {
+	ws.timeout = &startupTimeout
+	return ws
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ws *FileStrategy) WithPollInterval(pollInterval time.Duration) *FileStrategy {
+	ws.PollInterval = pollInterval
+	return ws
+}
+
+// WithBackoff can be used to replace the fixed poll interval by a backoff policy,
+// such as an exponential backoff for slow starting services
+func (ws *FileStrategy) WithBackoff(b Backoff) *FileStrategy {
+	ws.backoff = b
+	return ws
+}
+
+// WithMatcher can be used to check the content of the file. The strategy keeps
+// polling until the matcher returns nil.
+func (ws *FileStrategy) WithMatcher(matcher func(content io.Reader) error) *FileStrategy {
+	ws.Matcher = matcher
+	return ws
+}
+
+func (ws *FileStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (ws *FileStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	timeout := defaultStartupTimeout()
+	if ws.timeout != nil {
+		timeout = *ws.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	poll := pollBackoff(ctx, ws.backoff, ws.PollInterval)
+
+	lastErr := fmt.Errorf("file %q not found", ws.File)
+	for {
+		if err := checkTarget(ctx, target); err != nil {
+			return err
+		}
+
+		recordAttempt(ctx)
+		err := ws.checkFile(ctx, target)
+		if err == nil {
+			recordDetail(ctx, "file %s", ws.File)
+			return nil
+		}
+
+		var matchErr *fileMatchError
+		if !errdefs.IsNotFound(err) && !errors.As(err, &matchErr) {
+			return err
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), lastErr)
+		case <-time.After(nextInterval(poll)):
+		}
+	}
+}
+
+// fileMatchError is returned when the file exists but its content does not match yet
+type fileMatchError struct {
+	file string
+	err  error
+}
+
+func (e *fileMatchError) Error() string {
+	return fmt.Sprintf("file %q does not match: %s", e.file, e.err)
+}
+
+func (e *fileMatchError) Unwrap() error {
+	return e.err
+}
+
+func (ws *FileStrategy) checkFile(ctx context.Context, target StrategyTarget) error {
+	rc, err := target.CopyFileFromContainer(ctx, ws.File)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if ws.Matcher == nil {
+		return nil
+	}
+
+	if err := ws.Matcher(rc); err != nil {
+		return &fileMatchError{file: ws.File, err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForFileSucceeds(t *testing.T) {
+	var calls int
+	target := newRunningStrategyTarget("localhost", "")
+	target.CopyFileImpl = func(_ context.Context, filePath string) (io.ReadCloser, error) {
+		assert.Equal(t, "/etc/rancher/k3s/k3s.yaml", filePath)
+		defer func() { calls++ }()
+		if calls < 2 {
+			return nil, errdefs.NotFound(errors.New("Could not find the file"))
+		}
+		return io.NopCloser(strings.NewReader("apiVersion: v1")), nil
+	}
+
+	wg := ForFile("/etc/rancher/k3s/k3s.yaml").
+		WithStartupTimeout(500 * time.Millisecond).
+		WithPollInterval(10 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestWaitForFileWithMatcherSucceeds(t *testing.T) {
+	var calls int
+	target := newRunningStrategyTarget("localhost", "")
+	target.CopyFileImpl = func(_ context.Context, _ string) (io.ReadCloser, error) {
+		defer func() { calls++ }()
+		if calls < 2 {
+			return io.NopCloser(strings.NewReader("")), nil
+		}
+		return io.NopCloser(strings.NewReader("-----BEGIN CERTIFICATE-----")), nil
+	}
+
+	var content []byte
+	wg := ForFile("/usr/share/elasticsearch/config/certs/http_ca.crt").
+		WithStartupTimeout(500 * time.Millisecond).
+		WithPollInterval(10 * time.Millisecond).
+		WithMatcher(func(r io.Reader) error {
+			b, err := io.ReadAll(r)
+			if err != nil {
+				return err
+			}
+			if !bytes.Contains(b, []byte("CERTIFICATE")) {
+				return errors.New("certificate not written yet")
+			}
+			content = b
+			return nil
+		})
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.NoError(t, err)
+	assert.Equal(t, "-----BEGIN CERTIFICATE-----", string(content))
+}
+
+func TestWaitForFileTimesOutReportingLastError(t *testing.T) {
+	target := newRunningStrategyTarget("localhost", "")
+	target.CopyFileImpl = func(_ context.Context, _ string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("starting")), nil
+	}
+
+	wg := ForFile("/tmp/ready").
+		WithStartupTimeout(200 * time.Millisecond).
+		WithPollInterval(10 * time.Millisecond).
+		WithMatcher(func(r io.Reader) error {
+			return errors.New("not ready")
+		})
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), `file "/tmp/ready" does not match: not ready`)
+}
+
+func TestWaitForFileFailsOnCopyError(t *testing.T) {
+	target := newRunningStrategyTarget("localhost", "")
+	target.CopyFileImpl = func(_ context.Context, _ string) (io.ReadCloser, error) {
+		return nil, errors.New("daemon unavailable")
+	}
+
+	wg := ForFile("/tmp/ready").WithStartupTimeout(500 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.EqualError(t, err, "daemon unavailable")
+}
+
+func TestWaitForFileFailsDueToExitedContainer(t *testing.T) {
+	target := newRunningStrategyTarget("localhost", "")
+	target.StateImpl = func(_ context.Context) (*types.ContainerState, error) {
+		return &types.ContainerState{Status: "exited", ExitCode: 1}, nil
+	}
+
+	wg := ForFile("/tmp/ready").WithStartupTimeout(500 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.EqualError(t, err, "container exited with code 1")
+}
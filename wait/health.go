@@ -2,6 +2,8 @@ package wait
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -17,6 +19,8 @@ var (
 type HealthStrategy struct {
 	// all Strategies should have a startupTimeout to avoid waiting infinitely
 	timeout *time.Duration
+	// backoff defines the intervals between attempts, overriding the poll interval
+	backoff Backoff
 
 	// additional properties
 	PollInterval time.Duration
@@ -45,6 +49,13 @@ func (ws *HealthStrategy) WithPollInterval(pollInterval time.Duration) *HealthSt
 	return ws
 }
 
+// WithBackoff can be used to replace the fixed poll interval by a backoff policy,
+// such as an exponential backoff for slow starting services
+func (ws *HealthStrategy) WithBackoff(b Backoff) *HealthStrategy {
+	ws.backoff = b
+	return ws
+}
+
 // ForHealthCheck is the default construction for the fluid interface.
 //
 // For Example:
@@ -70,11 +81,18 @@ func (ws *HealthStrategy) WaitUntilReady(ctx context.Context, target StrategyTar
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	poll := pollBackoff(ctx, ws.backoff, ws.PollInterval)
+
+	var health *types.Health
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			if health == nil {
+				return ctx.Err()
+			}
+			return &HealthCheckError{Status: health.Status, Log: health.Log, err: ctx.Err()}
 		default:
+			recordAttempt(ctx)
 			state, err := target.State(ctx)
 			if err != nil {
 				return err
@@ -82,11 +100,49 @@ func (ws *HealthStrategy) WaitUntilReady(ctx context.Context, target StrategyTar
 			if err := checkState(state); err != nil {
 				return err
 			}
-			if state.Health == nil || state.Health.Status != types.Healthy {
-				time.Sleep(ws.PollInterval)
+			health = state.Health
+			if health != nil {
+				recordDetail(ctx, "health %s", health.Status)
+			}
+			if health != nil && health.Status == types.Unhealthy {
+				// the container is only marked as unhealthy once all the retries are exhausted
+				return &HealthCheckError{Status: health.Status, Log: health.Log}
+			}
+			if health == nil || health.Status != types.Healthy {
+				time.Sleep(nextInterval(poll))
 				continue
 			}
 			return nil
 		}
 	}
 }
+
+// HealthCheckError is returned by the HealthStrategy when the container does not become healthy.
+// It includes the results of the last health checks run by Docker.
+type HealthCheckError struct {
+	Status string
+	Log    []*types.HealthcheckResult
+	// err is the cause of the failure, if the strategy did not fail because the container is unhealthy
+	err error
+}
+
+func (e *HealthCheckError) Error() string {
+	var sb strings.Builder
+	if e.err != nil {
+		fmt.Fprintf(&sb, "%s: ", e.err)
+	}
+	fmt.Fprintf(&sb, "container health status is %q", e.Status)
+
+	if len(e.Log) > 0 {
+		sb.WriteString(", last health check outputs:")
+		for _, result := range e.Log {
+			fmt.Fprintf(&sb, "\n[exit code %d] %s", result.ExitCode, strings.TrimSpace(result.Output))
+		}
+	}
+
+	return sb.String()
+}
+
+func (e *HealthCheckError) Unwrap() error {
+	return e.err
+}
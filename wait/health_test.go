@@ -10,6 +10,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/go-connections/nat"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	tcexec "github.com/testcontainers/testcontainers-go/exec"
 )
@@ -38,24 +39,68 @@ func (st healthStrategyTarget) Exec(ctx context.Context, cmd []string, options .
 	return 0, nil, nil
 }
 
+func (st healthStrategyTarget) CopyFileFromContainer(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	return nil, nil
+}
+
 func (st healthStrategyTarget) State(ctx context.Context) (*types.ContainerState, error) {
 	return st.state, nil
 }
 
-// TestWaitForHealthTimesOutForUnhealthy confirms that an unhealthy container will eventually
-// time out.
-func TestWaitForHealthTimesOutForUnhealthy(t *testing.T) {
+// TestWaitForHealthFailsForUnhealthy confirms that an unhealthy container fails without
+// waiting for the timeout, reporting the outputs of the last health checks.
+func TestWaitForHealthFailsForUnhealthy(t *testing.T) {
 	target := healthStrategyTarget{
 		state: &types.ContainerState{
 			Running: true,
-			Health:  &types.Health{Status: types.Unhealthy},
+			Health: &types.Health{
+				Status:        types.Unhealthy,
+				FailingStreak: 2,
+				Log: []*types.HealthcheckResult{
+					{ExitCode: 1, Output: "connection refused\n"},
+					{ExitCode: 1, Output: "database is starting up\n"},
+				},
+			},
+		},
+	}
+	wg := NewHealthStrategy().WithStartupTimeout(time.Minute)
+
+	start := time.Now()
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+
+	var healthErr *HealthCheckError
+	require.True(t, errors.As(err, &healthErr))
+	assert.Equal(t, types.Unhealthy, healthErr.Status)
+	assert.Len(t, healthErr.Log, 2)
+	assert.False(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, "container health status is \"unhealthy\", last health check outputs:\n"+
+		"[exit code 1] connection refused\n"+
+		"[exit code 1] database is starting up", err.Error())
+}
+
+// TestWaitForHealthTimesOutForStarting confirms that a container which never becomes healthy
+// times out, reporting the outputs of the last health checks.
+func TestWaitForHealthTimesOutForStarting(t *testing.T) {
+	target := healthStrategyTarget{
+		state: &types.ContainerState{
+			Running: true,
+			Health: &types.Health{
+				Status: types.Starting,
+				Log: []*types.HealthcheckResult{
+					{ExitCode: 1, Output: "connection refused"},
+				},
+			},
 		},
 	}
 	wg := NewHealthStrategy().WithStartupTimeout(100 * time.Millisecond)
 	err := wg.WaitUntilReady(context.Background(), target)
 
-	assert.NotNil(t, err)
+	require.Error(t, err)
 	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), "container health status is \"starting\"")
+	assert.Contains(t, err.Error(), "[exit code 1] connection refused")
 }
 
 // TestWaitForHealthSucceeds ensures that a healthy container always succeeds.
@@ -1,16 +1,22 @@
 package wait
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/docker/go-connections/nat"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 )
 
 // Implement interface
@@ -26,7 +32,9 @@ type HostPortStrategy struct {
 	// which
 	Port nat.Port
 	// all WaitStrategies should have a startupTimeout to avoid waiting infinitely
-	timeout      *time.Duration
+	timeout *time.Duration
+	// backoff defines the intervals between attempts, overriding the poll interval
+	backoff      Backoff
 	PollInterval time.Duration
 }
 
@@ -66,6 +74,13 @@ func (hp *HostPortStrategy) WithPollInterval(pollInterval time.Duration) *HostPo
 	return hp
 }
 
+// WithBackoff can be used to replace the fixed poll interval by a backoff policy,
+// such as an exponential backoff for slow starting services
+func (hp *HostPortStrategy) WithBackoff(b Backoff) *HostPortStrategy {
+	hp.backoff = b
+	return hp
+}
+
 func (hp *HostPortStrategy) Timeout() *time.Duration {
 	return hp.timeout
 }
@@ -85,7 +100,7 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 		return err
 	}
 
-	waitInterval := hp.PollInterval
+	poll := pollBackoff(ctx, hp.backoff, hp.PollInterval)
 
 	internalPort := hp.Port
 	if internalPort == "" {
@@ -116,7 +131,7 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 		select {
 		case <-ctx.Done():
 			return fmt.Errorf("%w: %w", ctx.Err(), err)
-		case <-time.After(waitInterval):
+		case <-time.After(nextInterval(poll)):
 			if err := checkTarget(ctx, target); err != nil {
 				return err
 			}
@@ -127,21 +142,29 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 		}
 	}
 
-	if err := externalCheck(ctx, ipAddress, port, target, waitInterval); err != nil {
-		return err
+	// UDP is connectionless, so a UDP port can only be checked from inside the container
+	if port.Proto() != "udp" {
+		if err := externalCheck(ctx, ipAddress, port, target, poll); err != nil {
+			return err
+		}
 	}
 
-	err = internalCheck(ctx, internalPort, target)
-	if err != nil && errors.Is(errShellNotExecutable, err) {
-		log.Println("Shell not executable in container, only external port check will be performed")
-	} else {
+	err = internalCheck(ctx, internalPort, target, poll)
+	if err != nil && errors.Is(err, errShellNotExecutable) {
+		if port.Proto() == "udp" {
+			log.Println("Shell not executable in container, UDP port check will not be performed")
+		} else {
+			log.Println("Shell not executable in container, only external port check will be performed")
+		}
+	} else if err != nil {
 		return err
 	}
 
+	recordDetail(ctx, "port %s", internalPort)
 	return nil
 }
 
-func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target StrategyTarget, waitInterval time.Duration) error {
+func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target StrategyTarget, poll Backoff) error {
 	proto := port.Proto()
 	portNumber := port.Int()
 	portString := strconv.Itoa(portNumber)
@@ -152,6 +175,7 @@ func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target
 		if err := checkTarget(ctx, target); err != nil {
 			return err
 		}
+		recordAttempt(ctx)
 		conn, err := dialer.DialContext(ctx, proto, address)
 		if err != nil {
 			var v *net.OpError
@@ -159,7 +183,7 @@ func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target
 				var v2 *os.SyscallError
 				if errors.As(v.Err, &v2) {
 					if isConnRefusedErr(v2.Err) {
-						time.Sleep(waitInterval)
+						time.Sleep(nextInterval(poll))
 						continue
 					}
 				}
@@ -173,8 +197,8 @@ func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target
 	return nil
 }
 
-func internalCheck(ctx context.Context, internalPort nat.Port, target StrategyTarget) error {
-	command := buildInternalCheckCommand(internalPort.Int())
+func internalCheck(ctx context.Context, internalPort nat.Port, target StrategyTarget, poll Backoff) error {
+	command := buildInternalCheckCommand(internalPort)
 	for {
 		if ctx.Err() != nil {
 			return ctx.Err()
@@ -182,6 +206,7 @@ func internalCheck(ctx context.Context, internalPort nat.Port, target StrategyTa
 		if err := checkTarget(ctx, target); err != nil {
 			return err
 		}
+		recordAttempt(ctx)
 		exitCode, _, err := target.Exec(ctx, []string{"/bin/sh", "-c", command})
 		if err != nil {
 			return fmt.Errorf("%w, host port waiting failed", err)
@@ -189,19 +214,122 @@ func internalCheck(ctx context.Context, internalPort nat.Port, target StrategyTa
 
 		if exitCode == 0 {
 			break
-		} else if exitCode == 126 {
+		} else if exitCode == 126 || exitCode == 127 {
+			if nsTarget, ok := target.(NetworkNamespaceTarget); ok {
+				return networkNamespaceCheck(ctx, internalPort, nsTarget, target, poll)
+			}
 			return errShellNotExecutable
 		}
 	}
 	return nil
 }
 
-func buildInternalCheckCommand(internalPort int) string {
+// networkNamespaceCheck waits until the port is in the LISTEN state, or bound for UDP ports,
+// reading the sockets of the container from a helper sharing its network namespace. It's used
+// when the container has no shell to run the internal check command.
+func networkNamespaceCheck(ctx context.Context, internalPort nat.Port, nsTarget NetworkNamespaceTarget, target StrategyTarget, poll Backoff) error {
+	cmd := []string{"cat", "/proc/net/tcp", "/proc/net/tcp6"}
+	if internalPort.Proto() == "udp" {
+		cmd = []string{"cat", "/proc/net/udp", "/proc/net/udp6"}
+	}
+
+	for {
+		if err := checkTarget(ctx, target); err != nil {
+			return err
+		}
+		recordAttempt(ctx)
+		_, reader, err := nsTarget.ExecInNetworkNamespace(ctx, cmd, tcexec.Multiplexed())
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// the helper could not be run, e.g. its image cannot be pulled,
+			// so fall back to the external check only
+			return fmt.Errorf("%w: %w", errShellNotExecutable, err)
+		}
+
+		// the exit code is ignored, as cat fails when IPv6 is disabled and the tcp6 or udp6
+		// table doesn't exist, but it still prints the IPv4 table
+		if reader != nil {
+			sockets, err := io.ReadAll(reader)
+			if err != nil {
+				return err
+			}
+			if internalPort.Proto() == "udp" && isBound(sockets, internalPort.Int()) {
+				return nil
+			}
+			if internalPort.Proto() != "udp" && isListening(sockets, internalPort.Int()) {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(nextInterval(poll)):
+		}
+	}
+}
+
+const (
+	// tcpListenState is the value of the "st" column for TCP sockets in the LISTEN state
+	tcpListenState = "0A"
+	// udpUnconnectedState is the value of the "st" column for bound UDP sockets
+	// which are not connected to a remote address, as servers are
+	udpUnconnectedState = "07"
+)
+
+// isListening checks if the content of /proc/net/tcp or /proc/net/tcp6 includes
+// a socket listening on the given port
+func isListening(procNetTCP []byte, port int) bool {
+	return hasSocket(procNetTCP, port, tcpListenState)
+}
+
+// isBound checks if the content of /proc/net/udp or /proc/net/udp6 includes
+// a server socket bound to the given port
+func isBound(procNetUDP []byte, port int) bool {
+	return hasSocket(procNetUDP, port, udpUnconnectedState)
+}
+
+// hasSocket checks if the content of a /proc/net socket table includes a socket
+// with the given local port and state
+func hasSocket(procNet []byte, port int, state string) bool {
+	wantPort := fmt.Sprintf("%04X", port)
+
+	scanner := bufio.NewScanner(bytes.NewReader(procNet))
+	for scanner.Scan() {
+		// sl local_address rem_address st ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || fields[3] != state {
+			continue
+		}
+
+		i := strings.LastIndex(fields[1], ":")
+		if i < 0 {
+			continue
+		}
+
+		if strings.EqualFold(fields[1][i+1:], wantPort) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func buildInternalCheckCommand(internalPort nat.Port) string {
+	if internalPort.Proto() == "udp" {
+		// there is no way to probe a UDP port without knowing its protocol,
+		// so only check that a socket is bound to it
+		command := `cat /proc/net/udp* | awk '{print $2}' | grep -i :%04x`
+		return "true && " + fmt.Sprintf(command, internalPort.Int())
+	}
+
 	command := `(
 					cat /proc/net/tcp* | awk '{print $2}' | grep -i :%04x ||
 					nc -vz -w 1 localhost %d ||
 					/bin/sh -c '</dev/tcp/localhost/%d'
 				)
 				`
-	return "true && " + fmt.Sprintf(command, internalPort, internalPort, internalPort)
+	return "true && " + fmt.Sprintf(command, internalPort.Int(), internalPort.Int(), internalPort.Int())
 }
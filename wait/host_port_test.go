@@ -2,14 +2,17 @@ package wait
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/testcontainers/testcontainers-go/exec"
 )
@@ -532,3 +535,275 @@ func TestHostPortStrategySucceedsGivenShellIsNotInstalled(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// networkNamespaceStrategyTarget is a target able to run commands in its network namespace
+type networkNamespaceStrategyTarget struct {
+	*MockStrategyTarget
+	ExecInNetworkNamespaceImpl func(context.Context, []string, ...exec.ProcessOption) (int, io.Reader, error)
+}
+
+func (st networkNamespaceStrategyTarget) ExecInNetworkNamespace(ctx context.Context, cmd []string, options ...exec.ProcessOption) (int, io.Reader, error) {
+	return st.ExecInNetworkNamespaceImpl(ctx, cmd, options...)
+}
+
+const (
+	// header and a socket connected from port 80 to port 8080, which is not listening
+	procNetTCPConnected = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 0100007F:0050 0100007F:1F90 01 00000000:00000000 00:00000000 00000000     0        0 1 1 0000000000000000 100 0 0 10 0
+`
+	// a socket listening on port 8080 in IPv6
+	procNetTCP6Listening = `   0: 00000000000000000000000000000000:1F90 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 2 1 0000000000000000 100 0 0 10 0
+`
+)
+
+func TestIsListening(t *testing.T) {
+	assert.False(t, isListening([]byte(procNetTCPConnected), 80))
+	assert.False(t, isListening([]byte(procNetTCPConnected), 8080))
+	assert.True(t, isListening([]byte(procNetTCPConnected+procNetTCP6Listening), 8080))
+	assert.False(t, isListening([]byte(procNetTCP6Listening), 80))
+}
+
+func TestHostPortStrategyChecksNetworkNamespaceGivenShellIsNotInstalled(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	rawPort := listener.Addr().(*net.TCPAddr).Port
+	port, err := nat.NewPort("tcp", strconv.Itoa(rawPort))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var nsExecCount int
+	target := networkNamespaceStrategyTarget{
+		MockStrategyTarget: &MockStrategyTarget{
+			HostImpl: func(_ context.Context) (string, error) {
+				return "localhost", nil
+			},
+			MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+				return port, nil
+			},
+			StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+				return &types.ContainerState{
+					Running: true,
+				}, nil
+			},
+			ExecImpl: func(_ context.Context, _ []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+				return 126, nil, nil
+			},
+		},
+		ExecInNetworkNamespaceImpl: func(_ context.Context, cmd []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+			assert.Equal(t, []string{"cat", "/proc/net/tcp", "/proc/net/tcp6"}, cmd)
+			defer func() { nsExecCount++ }()
+			if nsExecCount == 0 {
+				return 0, strings.NewReader(procNetTCPConnected), nil
+			}
+			return 0, strings.NewReader(procNetTCPConnected + procNetTCP6Listening), nil
+		},
+	}
+
+	wg := NewHostPortStrategy("8080").
+		WithStartupTimeout(5 * time.Second).
+		WithPollInterval(100 * time.Millisecond)
+
+	if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, nsExecCount)
+}
+
+func TestHostPortStrategyChecksNetworkNamespaceGivenIPv6IsDisabled(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	rawPort := listener.Addr().(*net.TCPAddr).Port
+	port, err := nat.NewPort("tcp", strconv.Itoa(rawPort))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a socket listening on port 8080 in IPv4
+	procNetTCPListening := `   1: 00000000:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 3 1 0000000000000000 100 0 0 10 0
+`
+
+	target := networkNamespaceStrategyTarget{
+		MockStrategyTarget: &MockStrategyTarget{
+			HostImpl: func(_ context.Context) (string, error) {
+				return "localhost", nil
+			},
+			MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+				return port, nil
+			},
+			StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+				return &types.ContainerState{
+					Running: true,
+				}, nil
+			},
+			ExecImpl: func(_ context.Context, _ []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+				return 126, nil, nil
+			},
+		},
+		ExecInNetworkNamespaceImpl: func(_ context.Context, _ []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+			// cat fails for the missing /proc/net/tcp6, printing /proc/net/tcp anyway
+			return 1, strings.NewReader(procNetTCPConnected + procNetTCPListening), nil
+		},
+	}
+
+	wg := NewHostPortStrategy("8080").
+		WithStartupTimeout(time.Second).
+		WithPollInterval(100 * time.Millisecond)
+
+	if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHostPortStrategySucceedsGivenNetworkNamespaceHelperFails(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	rawPort := listener.Addr().(*net.TCPAddr).Port
+	port, err := nat.NewPort("tcp", strconv.Itoa(rawPort))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := networkNamespaceStrategyTarget{
+		MockStrategyTarget: &MockStrategyTarget{
+			HostImpl: func(_ context.Context) (string, error) {
+				return "localhost", nil
+			},
+			MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+				return port, nil
+			},
+			StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+				return &types.ContainerState{
+					Running: true,
+				}, nil
+			},
+			ExecImpl: func(_ context.Context, _ []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+				return 126, nil, nil
+			},
+		},
+		ExecInNetworkNamespaceImpl: func(_ context.Context, _ []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+			return 0, nil, errors.New("image not found")
+		},
+	}
+
+	wg := NewHostPortStrategy("8080").
+		WithStartupTimeout(5 * time.Second).
+		WithPollInterval(100 * time.Millisecond)
+
+	if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+		t.Fatal(err)
+	}
+}
+
+const (
+	// header and a UDP socket connected from port 53 to port 5353
+	procNetUDPConnected = `   sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+  1: 0100007F:0035 0100007F:14E9 01 00000000:00000000 00:00000000 00000000     0        0 1 2 0000000000000000 0
+`
+	// a UDP socket bound to port 53 in IPv6
+	procNetUDP6Bound = `  0: 00000000000000000000000000000000:0035 00000000000000000000000000000000:0000 07 00000000:00000000 00:00000000 00000000     0        0 2 2 0000000000000000 0
+`
+)
+
+func TestIsBound(t *testing.T) {
+	assert.False(t, isBound([]byte(procNetUDPConnected), 53))
+	assert.False(t, isBound([]byte(procNetUDPConnected), 5353))
+	assert.True(t, isBound([]byte(procNetUDPConnected+procNetUDP6Bound), 53))
+	assert.False(t, isBound([]byte(procNetTCP6Listening), 8080))
+}
+
+func TestHostPortStrategyChecksUDPPortInternally(t *testing.T) {
+	var execCount int
+	target := &MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "localhost", nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			// nothing listens on the host, UDP ports are not checked externally
+			return "49152/udp", nil
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{
+				Running: true,
+			}, nil
+		},
+		ExecImpl: func(_ context.Context, cmd []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+			assert.Contains(t, cmd[2], "cat /proc/net/udp* | awk '{print $2}' | grep -i :0035")
+			defer func() { execCount++ }()
+			if execCount == 0 {
+				return 1, nil, nil
+			}
+			return 0, nil, nil
+		},
+	}
+
+	wg := NewHostPortStrategy("53/udp").
+		WithStartupTimeout(5 * time.Second).
+		WithPollInterval(100 * time.Millisecond)
+
+	if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, execCount)
+}
+
+func TestHostPortStrategyChecksUDPPortInNetworkNamespaceGivenShellIsNotInstalled(t *testing.T) {
+	var nsExecCount int
+	target := networkNamespaceStrategyTarget{
+		MockStrategyTarget: &MockStrategyTarget{
+			HostImpl: func(_ context.Context) (string, error) {
+				return "localhost", nil
+			},
+			PortsImpl: func(_ context.Context) (nat.PortMap, error) {
+				return nat.PortMap{
+					"53/udp": []nat.PortBinding{
+						{
+							HostIP:   "0.0.0.0",
+							HostPort: "49152",
+						},
+					},
+				}, nil
+			},
+			MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+				return "49152/udp", nil
+			},
+			StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+				return &types.ContainerState{
+					Running: true,
+				}, nil
+			},
+			ExecImpl: func(_ context.Context, _ []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+				return 127, nil, nil
+			},
+		},
+		ExecInNetworkNamespaceImpl: func(_ context.Context, cmd []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+			assert.Equal(t, []string{"cat", "/proc/net/udp", "/proc/net/udp6"}, cmd)
+			defer func() { nsExecCount++ }()
+			if nsExecCount == 0 {
+				return 0, strings.NewReader(procNetUDPConnected), nil
+			}
+			return 0, strings.NewReader(procNetUDPConnected + procNetUDP6Bound), nil
+		},
+	}
+
+	wg := ForExposedPort().
+		WithStartupTimeout(5 * time.Second).
+		WithPollInterval(100 * time.Millisecond)
+
+	if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, nsExecCount)
+}
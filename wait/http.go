@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/docker/go-connections/nat"
@@ -25,6 +26,8 @@ var (
 type HTTPStrategy struct {
 	// all Strategies should have a startupTimeout to avoid waiting infinitely
 	timeout *time.Duration
+	// backoff defines the intervals between attempts, overriding the poll interval
+	backoff Backoff
 
 	// additional properties
 	Port              nat.Port
@@ -38,6 +41,23 @@ type HTTPStrategy struct {
 	Body              io.Reader   // http request body
 	PollInterval      time.Duration
 	UserInfo          *url.Userinfo
+	Headers           map[string]string
+	// BearerTokenFunc returns the token sent in the Authorization header, it is evaluated
+	// against the target before each request
+	BearerTokenFunc func(ctx context.Context, target StrategyTarget) (string, error)
+	// JSONMatchers are JSONPath-like expressions which the response body must match,
+	// e.g. `$.status == "green"`
+	JSONMatchers []string
+
+	lastResponse    *HTTPResponse
+	lastResponseMtx sync.Mutex
+}
+
+// HTTPResponse is the last response received by the HTTPStrategy
+type HTTPResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
 }
 
 // NewHTTPStrategy constructs a HTTP strategy waiting on port 80 and status code 200
@@ -53,6 +73,7 @@ func NewHTTPStrategy(path string) *HTTPStrategy {
 		Body:              nil,
 		PollInterval:      defaultPollInterval(),
 		UserInfo:          nil,
+		Headers:           map[string]string{},
 	}
 }
 
@@ -113,12 +134,64 @@ func (ws *HTTPStrategy) WithBasicAuth(username, password string) *HTTPStrategy {
 	return ws
 }
 
+// WithHeaders can be used to add headers to the request
+func (ws *HTTPStrategy) WithHeaders(headers map[string]string) *HTTPStrategy {
+	if ws.Headers == nil {
+		ws.Headers = map[string]string{}
+	}
+	for k, v := range headers {
+		ws.Headers[k] = v
+	}
+	return ws
+}
+
+// WithBearerToken can be used to send the given token in the Authorization header
+func (ws *HTTPStrategy) WithBearerToken(token string) *HTTPStrategy {
+	ws.BearerTokenFunc = func(_ context.Context, _ StrategyTarget) (string, error) {
+		return token, nil
+	}
+	return ws
+}
+
+// WithBearerTokenFunc can be used to send a token obtained from the target in the Authorization header,
+// e.g. reading a file generated by the container. It is evaluated before each request, and the request
+// is retried after the poll interval if it returns an error.
+func (ws *HTTPStrategy) WithBearerTokenFunc(fn func(ctx context.Context, target StrategyTarget) (string, error)) *HTTPStrategy {
+	ws.BearerTokenFunc = fn
+	return ws
+}
+
+// WithJSONMatcher can be used to check the response body with a JSONPath-like expression,
+// such as `$.status == "green"` or `$.nodes[0].status == "healthy"`. The path starts with $,
+// followed by fields and array indexes, and it can be compared with a JSON value using ==, !=,
+// <, <=, > or >=. Without comparison, the path must exist and be neither null nor false.
+// It can be called several times, and all the expressions must match.
+func (ws *HTTPStrategy) WithJSONMatcher(expr string) *HTTPStrategy {
+	ws.JSONMatchers = append(ws.JSONMatchers, expr)
+	return ws
+}
+
+// LastResponse returns the last response received while waiting, or nil if no response was received
+func (ws *HTTPStrategy) LastResponse() *HTTPResponse {
+	ws.lastResponseMtx.Lock()
+	defer ws.lastResponseMtx.Unlock()
+
+	return ws.lastResponse
+}
+
 // WithPollInterval can be used to override the default polling interval of 100 milliseconds
 func (ws *HTTPStrategy) WithPollInterval(pollInterval time.Duration) *HTTPStrategy {
 	ws.PollInterval = pollInterval
 	return ws
 }
 
+// WithBackoff can be used to replace the fixed poll interval by a backoff policy,
+// such as an exponential backoff for slow starting services
+func (ws *HTTPStrategy) WithBackoff(b Backoff) *HTTPStrategy {
+	ws.backoff = b
+	return ws
+}
+
 // ForHTTP is a convenience method similar to Wait.java
 // https://github.com/testcontainers/testcontainers-java/blob/1d85a3834bd937f80aad3a4cec249c027f31aeb4/core/src/main/java/org/testcontainers/containers/wait/strategy/Wait.java
 func ForHTTP(path string) *HTTPStrategy {
@@ -139,6 +212,8 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	poll := pollBackoff(ctx, ws.backoff, ws.PollInterval)
+
 	ipAddress, err := target.Host(ctx)
 	if err != nil {
 		return err
@@ -153,7 +228,7 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 			select {
 			case <-ctx.Done():
 				return fmt.Errorf("%w: %w", ctx.Err(), err)
-			case <-time.After(ws.PollInterval):
+			case <-time.After(nextInterval(poll)):
 				if err := checkTarget(ctx, target); err != nil {
 					return err
 				}
@@ -180,7 +255,7 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 			select {
 			case <-ctx.Done():
 				return fmt.Errorf("%w: %w", ctx.Err(), err)
-			case <-time.After(ws.PollInterval):
+			case <-time.After(nextInterval(poll)):
 				if err := checkTarget(ctx, target); err != nil {
 					return err
 				}
@@ -256,11 +331,24 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		}
 	}
 
+	jsonMatchers := make([]*jsonExpression, 0, len(ws.JSONMatchers))
+	for _, expr := range ws.JSONMatchers {
+		matcher, err := parseJSONExpression(expr)
+		if err != nil {
+			return err
+		}
+		jsonMatchers = append(jsonMatchers, matcher)
+	}
+
+	var lastErr error
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(ws.PollInterval):
+			if lastErr == nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("%w: %w", ctx.Err(), lastErr)
+		case <-time.After(nextInterval(poll)):
 			if err := checkTarget(ctx, target); err != nil {
 				return err
 			}
@@ -268,22 +356,65 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 			if err != nil {
 				return err
 			}
-			resp, err := client.Do(req)
-			if err != nil {
+			recordAttempt(ctx)
+			if lastErr = ws.prepareRequest(ctx, target, req); lastErr != nil {
 				continue
 			}
-			if ws.StatusCodeMatcher != nil && !ws.StatusCodeMatcher(resp.StatusCode) {
-				_ = resp.Body.Close()
-				continue
-			}
-			if ws.ResponseMatcher != nil && !ws.ResponseMatcher(resp.Body) {
-				_ = resp.Body.Close()
-				continue
-			}
-			if err := resp.Body.Close(); err != nil {
+			if lastErr = ws.doRequest(&client, req, jsonMatchers); lastErr != nil {
 				continue
 			}
 			return nil
 		}
 	}
 }
+
+// prepareRequest sets the headers of the request
+func (ws *HTTPStrategy) prepareRequest(ctx context.Context, target StrategyTarget, req *http.Request) error {
+	for k, v := range ws.Headers {
+		req.Header.Set(k, v)
+	}
+
+	if ws.BearerTokenFunc != nil {
+		token, err := ws.BearerTokenFunc(ctx, target)
+		if err != nil {
+			return fmt.Errorf("bearer token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return nil
+}
+
+// doRequest sends the request and checks the response with the matchers
+func (ws *HTTPStrategy) doRequest(client *http.Client, req *http.Request, jsonMatchers []*jsonExpression) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	ws.lastResponseMtx.Lock()
+	ws.lastResponse = &HTTPResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: respBody}
+	ws.lastResponseMtx.Unlock()
+
+	recordDetail(req.Context(), "http %s %s %d", req.Method, req.URL.Path, resp.StatusCode)
+
+	if ws.StatusCodeMatcher != nil && !ws.StatusCodeMatcher(resp.StatusCode) {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	if ws.ResponseMatcher != nil && !ws.ResponseMatcher(bytes.NewReader(respBody)) {
+		return errors.New("response does not match")
+	}
+	for _, matcher := range jsonMatchers {
+		if err := matcher.match(respBody); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
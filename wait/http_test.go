@@ -5,18 +5,24 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -702,3 +708,115 @@ func TestHttpStrategyFailsWhileGettingPortDueToExposedPortNoBindings(t *testing.
 		}
 	}
 }
+
+// newHTTPStrategyTarget returns a target whose mapped port is the port of the test server
+func newHTTPStrategyTarget(t *testing.T, server *httptest.Server) *wait.MockStrategyTarget {
+	t.Helper()
+
+	_, rawPort, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+
+	return &wait.MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "localhost", nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			return nat.NewPort("tcp", rawPort)
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+		CopyFileImpl: func(_ context.Context, _ string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("s3cr3t\n")), nil
+		},
+	}
+}
+
+func TestHTTPStrategyWithHeadersAndBearerToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Request-Id") != "wait" || r.Header.Get("Authorization") != "Bearer s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	target := newHTTPStrategyTarget(t, server)
+
+	wg := wait.ForHTTP("/").
+		WithPort("8080/tcp").
+		WithHeaders(map[string]string{"X-Request-Id": "wait"}).
+		WithBearerTokenFunc(func(ctx context.Context, target wait.StrategyTarget) (string, error) {
+			rc, err := target.CopyFileFromContainer(ctx, "/run/secrets/token")
+			if err != nil {
+				return "", err
+			}
+			defer rc.Close()
+
+			token, err := io.ReadAll(rc)
+			return strings.TrimSpace(string(token)), err
+		}).
+		WithStartupTimeout(time.Second)
+
+	require.NoError(t, wg.WaitUntilReady(context.Background(), target))
+	assert.Equal(t, http.StatusOK, wg.LastResponse().StatusCode)
+}
+
+func TestHTTPStrategyWithJSONMatcher(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := "yellow"
+		if atomic.AddInt32(&requests, 1) >= 3 {
+			status = "green"
+		}
+		_, _ = fmt.Fprintf(w, `{"cluster_name":"docker-cluster","status":%q,"number_of_nodes":1}`, status)
+	}))
+	defer server.Close()
+
+	target := newHTTPStrategyTarget(t, server)
+
+	wg := wait.ForHTTP("/_cluster/health").
+		WithPort("9200/tcp").
+		WithBearerToken("s3cr3t").
+		WithJSONMatcher(`$.status == "green"`).
+		WithJSONMatcher(`$.number_of_nodes >= 1`).
+		WithPollInterval(10 * time.Millisecond).
+		WithStartupTimeout(time.Second)
+
+	require.NoError(t, wg.WaitUntilReady(context.Background(), target))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	assert.Contains(t, string(wg.LastResponse().Body), `"status":"green"`)
+}
+
+func TestHTTPStrategyTimesOutReportingLastError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"red"}`))
+	}))
+	defer server.Close()
+
+	target := newHTTPStrategyTarget(t, server)
+
+	wg := wait.ForHTTP("/_cluster/health").
+		WithPort("9200/tcp").
+		WithJSONMatcher(`$.status == "green"`).
+		WithStartupTimeout(500 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), `"$.status == \"green\"": actual value is "red"`)
+}
+
+func TestHTTPStrategyFailsForInvalidJSONMatcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	wg := wait.ForHTTP("/").
+		WithPort("8080/tcp").
+		WithJSONMatcher(`status == "green"`).
+		WithStartupTimeout(time.Second)
+
+	err := wg.WaitUntilReady(context.Background(), newHTTPStrategyTarget(t, server))
+	require.EqualError(t, err, `invalid JSON expression "status == \"green\"": path must start with $`)
+}
@@ -0,0 +1,184 @@
+package wait
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// jsonExpression is a condition on a JSON document, written as a JSONPath-like expression
+// such as `$.status == "green"` or `$.nodes[0].status != "unhealthy"`.
+//
+// The path starts with `$`, followed by fields (`.name` or `["name"]`) and array indexes (`[0]`).
+// It can be compared with a JSON literal using `==`, `!=`, `<`, `<=`, `>` or `>=`, the latter four
+// only for numbers. Without comparison, the expression matches if the path exists and is neither
+// null nor false.
+type jsonExpression struct {
+	expr     string
+	path     []interface{} // string for fields, int for indexes
+	operator string
+	value    interface{}
+}
+
+var jsonOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// parseJSONExpression parses the given expression
+func parseJSONExpression(expr string) (*jsonExpression, error) {
+	je := &jsonExpression{expr: expr}
+
+	s := strings.TrimSpace(expr)
+	if !strings.HasPrefix(s, "$") {
+		return nil, fmt.Errorf("invalid JSON expression %q: path must start with $", expr)
+	}
+	s = s[1:]
+
+	for len(s) > 0 && (s[0] == '.' || s[0] == '[') {
+		var err error
+		if s[0] == '.' {
+			s, err = je.parseField(s[1:])
+		} else {
+			s, err = je.parseIndex(s[1:])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON expression %q: %w", expr, err)
+		}
+	}
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return je, nil
+	}
+
+	for _, op := range jsonOperators {
+		if strings.HasPrefix(s, op) {
+			je.operator = op
+			s = strings.TrimSpace(s[len(op):])
+			break
+		}
+	}
+	if je.operator == "" {
+		return nil, fmt.Errorf("invalid JSON expression %q: unexpected %q", expr, s)
+	}
+
+	if err := json.Unmarshal([]byte(s), &je.value); err != nil {
+		return nil, fmt.Errorf("invalid JSON expression %q: value %q is not a JSON literal: %w", expr, s, err)
+	}
+
+	if je.operator != "==" && je.operator != "!=" {
+		if _, ok := je.value.(float64); !ok {
+			return nil, fmt.Errorf("invalid JSON expression %q: operator %s needs a number", expr, je.operator)
+		}
+	}
+
+	return je, nil
+}
+
+// parseField parses a field name after a dot, returning the rest of the expression
+func (je *jsonExpression) parseField(s string) (string, error) {
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return r == '.' || r == '[' || r == ' ' || strings.ContainsRune("=!<>", r)
+	})
+	if end == -1 {
+		end = len(s)
+	}
+	if end == 0 {
+		return "", errors.New("empty field name")
+	}
+
+	je.path = append(je.path, s[:end])
+	return s[end:], nil
+}
+
+// parseIndex parses an array index or a quoted field name between brackets, returning the rest of the expression
+func (je *jsonExpression) parseIndex(s string) (string, error) {
+	end := strings.IndexByte(s, ']')
+	if end == -1 {
+		return "", errors.New("missing ]")
+	}
+
+	content := strings.TrimSpace(s[:end])
+	if strings.HasPrefix(content, `"`) {
+		var field string
+		if err := json.Unmarshal([]byte(content), &field); err != nil {
+			return "", fmt.Errorf("invalid field name %s: %w", content, err)
+		}
+		je.path = append(je.path, field)
+		return s[end+1:], nil
+	}
+
+	index, err := strconv.Atoi(content)
+	if err != nil || index < 0 {
+		return "", fmt.Errorf("invalid array index %q", content)
+	}
+	je.path = append(je.path, index)
+	return s[end+1:], nil
+}
+
+// match evaluates the expression against the given JSON document
+func (je *jsonExpression) match(document []byte) error {
+	var current interface{}
+	if err := json.Unmarshal(document, &current); err != nil {
+		return fmt.Errorf("response is not a JSON document: %w", err)
+	}
+
+	for _, elem := range je.path {
+		var ok bool
+		switch e := elem.(type) {
+		case string:
+			var obj map[string]interface{}
+			if obj, ok = current.(map[string]interface{}); ok {
+				current, ok = obj[e]
+			}
+		case int:
+			var arr []interface{}
+			if arr, ok = current.([]interface{}); ok && e < len(arr) {
+				current = arr[e]
+			} else {
+				ok = false
+			}
+		}
+		if !ok {
+			return fmt.Errorf("%q: path not found", je.expr)
+		}
+	}
+
+	if je.operator == "" {
+		if current == nil || current == false {
+			return fmt.Errorf("%q: value is %v", je.expr, current)
+		}
+		return nil
+	}
+
+	var matched bool
+	switch je.operator {
+	case "==":
+		matched = reflect.DeepEqual(current, je.value)
+	case "!=":
+		matched = !reflect.DeepEqual(current, je.value)
+	default:
+		number, ok := current.(float64)
+		if !ok {
+			return fmt.Errorf("%q: value %v is not a number", je.expr, current)
+		}
+		expected := je.value.(float64)
+		switch je.operator {
+		case "<":
+			matched = number < expected
+		case "<=":
+			matched = number <= expected
+		case ">":
+			matched = number > expected
+		case ">=":
+			matched = number >= expected
+		}
+	}
+
+	if !matched {
+		actual, _ := json.Marshal(current)
+		return fmt.Errorf("%q: actual value is %s", je.expr, actual)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package wait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONExpression(t *testing.T) {
+	document := []byte(`{
+		"status": "green",
+		"initialized": true,
+		"sealed": false,
+		"version": null,
+		"number_of_nodes": 3,
+		"nodes": [{"status": "healthy"}, {"status": "warmup"}],
+		"cluster.name": "docker-cluster"
+	}`)
+
+	tests := []struct {
+		expr    string
+		matches bool
+	}{
+		{expr: `$.status == "green"`, matches: true},
+		{expr: `$.status=="green"`, matches: true},
+		{expr: `$.status != "green"`, matches: false},
+		{expr: `$.status == "yellow"`, matches: false},
+		{expr: `$.initialized == true`, matches: true},
+		{expr: `$.sealed == false`, matches: true},
+		{expr: `$.initialized`, matches: true},
+		{expr: `$.sealed`, matches: false},
+		{expr: `$.version`, matches: false},
+		{expr: `$.missing`, matches: false},
+		{expr: `$.number_of_nodes == 3`, matches: true},
+		{expr: `$.number_of_nodes >= 3`, matches: true},
+		{expr: `$.number_of_nodes > 3`, matches: false},
+		{expr: `$.number_of_nodes < 4`, matches: true},
+		{expr: `$.number_of_nodes <= 2`, matches: false},
+		{expr: `$.status > 2`, matches: false},
+		{expr: `$.nodes[0].status == "healthy"`, matches: true},
+		{expr: `$.nodes[1].status == "healthy"`, matches: false},
+		{expr: `$.nodes[2].status == "healthy"`, matches: false},
+		{expr: `$.nodes[0]`, matches: true},
+		{expr: `$["cluster.name"] == "docker-cluster"`, matches: true},
+		{expr: `$.nodes == [{"status": "healthy"}, {"status": "warmup"}]`, matches: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			je, err := parseJSONExpression(tt.expr)
+			require.NoError(t, err)
+
+			err = je.match(document)
+			if tt.matches {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestJSONExpressionErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{expr: `status == "green"`, err: `invalid JSON expression "status == \"green\"": path must start with $`},
+		{expr: `$.status = "green"`, err: `invalid JSON expression "$.status = \"green\"": unexpected "= \"green\""`},
+		{expr: `$.status == green`, err: `invalid JSON expression "$.status == green": value "green" is not a JSON literal: invalid character 'g' looking for beginning of value`},
+		{expr: `$.nodes[0.status == "healthy"`, err: `invalid JSON expression "$.nodes[0.status == \"healthy\"": missing ]`},
+		{expr: `$.nodes[-1]`, err: `invalid JSON expression "$.nodes[-1]": invalid array index "-1"`},
+		{expr: `$..status`, err: `invalid JSON expression "$..status": empty field name`},
+		{expr: `$.status > "green"`, err: `invalid JSON expression "$.status > \"green\"": operator > needs a number`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := parseJSONExpression(tt.expr)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestJSONExpressionInvalidDocument(t *testing.T) {
+	je, err := parseJSONExpression(`$.status == "green"`)
+	require.NoError(t, err)
+
+	err = je.match([]byte("<html></html>"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "response is not a JSON document")
+}
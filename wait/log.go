@@ -18,6 +18,8 @@ var (
 type LogStrategy struct {
 	// all Strategies should have a startupTimeout to avoid waiting infinitely
 	timeout *time.Duration
+	// backoff defines the intervals between attempts, overriding the poll interval
+	backoff Backoff
 
 	// additional properties
 	Log          string
@@ -58,6 +60,13 @@ func (ws *LogStrategy) WithPollInterval(pollInterval time.Duration) *LogStrategy
 	return ws
 }
 
+// WithBackoff can be used to replace the fixed poll interval by a backoff policy,
+// such as an exponential backoff for slow starting services
+func (ws *LogStrategy) WithBackoff(b Backoff) *LogStrategy {
+	ws.backoff = b
+	return ws
+}
+
 func (ws *LogStrategy) WithOccurrence(o int) *LogStrategy {
 	// the number of occurrence needs to be positive
 	if o <= 0 {
@@ -92,6 +101,8 @@ func (ws *LogStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	poll := pollBackoff(ctx, ws.backoff, ws.PollInterval)
+
 	length := 0
 
 LOOP:
@@ -102,15 +113,16 @@ LOOP:
 		default:
 			checkErr := checkTarget(ctx, target)
 
+			recordAttempt(ctx)
 			reader, err := target.Logs(ctx)
 			if err != nil {
-				time.Sleep(ws.PollInterval)
+				time.Sleep(nextInterval(poll))
 				continue
 			}
 
 			b, err := io.ReadAll(reader)
 			if err != nil {
-				time.Sleep(ws.PollInterval)
+				time.Sleep(nextInterval(poll))
 				continue
 			}
 
@@ -120,10 +132,11 @@ LOOP:
 			case length == len(logs) && checkErr != nil:
 				return checkErr
 			case checkLogsFn(ws, b):
+				recordDetail(ctx, "log %q", ws.Log)
 				break LOOP
 			default:
 				length = len(logs)
-				time.Sleep(ws.PollInterval)
+				time.Sleep(nextInterval(poll))
 				continue
 			}
 		}
@@ -0,0 +1,115 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ReadinessReport describes how long a strategy waited for a container to be ready,
+// which allows to find the dependencies dominating the startup of a test suite.
+type ReadinessReport struct {
+	// Strategy is the type of the strategy, e.g. *wait.HTTPStrategy
+	Strategy string
+	Start    time.Time
+	End      time.Time
+	// Attempts is the number of times the strategies checked the container, including
+	// the nested strategies of a MultiStrategy
+	Attempts int
+	// Detail describes the last check, e.g. "http GET /health 200", if the strategy provides it
+	Detail string
+	// Err is the error returned by the strategy, nil if the container is ready
+	Err error
+}
+
+// Duration returns how long the strategy waited
+func (r *ReadinessReport) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// Ready returns true if the container is ready
+func (r *ReadinessReport) Ready() bool {
+	return r.Err == nil
+}
+
+// String returns a summary of the report, e.g. "ready in 4.2s after 37 attempts (http GET /health 200)"
+func (r *ReadinessReport) String() string {
+	d := r.Duration().Round(time.Millisecond)
+	if d >= time.Second {
+		d = d.Round(100 * time.Millisecond)
+	}
+
+	attempts := "attempts"
+	if r.Attempts == 1 {
+		attempts = "attempt"
+	}
+
+	detail := r.Strategy
+	if r.Detail != "" {
+		detail = r.Detail
+	}
+
+	if r.Err != nil {
+		return fmt.Sprintf("not ready after %s and %d %s (%s): %s", d, r.Attempts, attempts, detail, r.Err)
+	}
+	return fmt.Sprintf("ready in %s after %d %s (%s)", d, r.Attempts, attempts, detail)
+}
+
+// WaitUntilReadyWithReport runs the strategy against the target, reporting its timing and outcome.
+// The returned error is the error of the strategy, also available in the report.
+func WaitUntilReadyWithReport(ctx context.Context, strategy Strategy, target StrategyTarget) (*ReadinessReport, error) {
+	recorder := &readinessRecorder{}
+	ctx = context.WithValue(ctx, readinessRecorderKey{}, recorder)
+
+	report := &ReadinessReport{
+		Strategy: fmt.Sprintf("%T", strategy),
+		Start:    time.Now(),
+	}
+
+	report.Err = strategy.WaitUntilReady(ctx, target)
+	report.End = time.Now()
+
+	recorder.mtx.Lock()
+	report.Attempts = recorder.attempts
+	report.Detail = recorder.detail
+	recorder.mtx.Unlock()
+
+	return report, report.Err
+}
+
+// readinessRecorderKey is the context key for the readinessRecorder of WaitUntilReadyWithReport
+type readinessRecorderKey struct{}
+
+// readinessRecorder collects the attempts of the strategies, which can run concurrently in a MultiStrategy
+type readinessRecorder struct {
+	mtx      sync.Mutex
+	attempts int
+	detail   string
+}
+
+// recordAttempt counts an attempt of a strategy to check the container, if the readiness is being reported
+func recordAttempt(ctx context.Context) {
+	recorder, ok := ctx.Value(readinessRecorderKey{}).(*readinessRecorder)
+	if !ok {
+		return
+	}
+
+	recorder.mtx.Lock()
+	defer recorder.mtx.Unlock()
+
+	recorder.attempts++
+}
+
+// recordDetail describes the last check done by a strategy, if the readiness is being reported
+func recordDetail(ctx context.Context, format string, args ...interface{}) {
+	recorder, ok := ctx.Value(readinessRecorderKey{}).(*readinessRecorder)
+	if !ok {
+		return
+	}
+
+	recorder.mtx.Lock()
+	defer recorder.mtx.Unlock()
+
+	recorder.detail = fmt.Sprintf(format, args...)
+}
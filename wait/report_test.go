@@ -0,0 +1,125 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitUntilReadyWithReport(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	port := nat.Port(strings.TrimPrefix(server.URL, "http://127.0.0.1:") + "/tcp")
+	target := &MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "127.0.0.1", nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			return port, nil
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+	}
+
+	wg := ForHTTP("/health").
+		WithPort("8080/tcp").
+		WithPollInterval(10 * time.Millisecond).
+		WithStartupTimeout(time.Second)
+
+	report, err := WaitUntilReadyWithReport(context.Background(), wg, target)
+	require.NoError(t, err)
+	assert.True(t, report.Ready())
+	assert.Equal(t, "*wait.HTTPStrategy", report.Strategy)
+	assert.Equal(t, 3, report.Attempts)
+	assert.Equal(t, "http GET /health 200", report.Detail)
+	assert.False(t, report.End.Before(report.Start))
+	assert.Regexp(t, `^ready in \d+ms after 3 attempts \(http GET /health 200\)$`, report.String())
+}
+
+func TestWaitUntilReadyWithReportForMultiStrategy(t *testing.T) {
+	var logsCalls int
+	target := &MockStrategyTarget{
+		LogsImpl: func(_ context.Context) (io.ReadCloser, error) {
+			logsCalls++
+			if logsCalls < 3 {
+				return io.NopCloser(strings.NewReader("starting")), nil
+			}
+			return io.NopCloser(strings.NewReader("ready")), nil
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+	}
+
+	wg := ForAll(
+		ForLog("starting").WithPollInterval(10*time.Millisecond),
+		ForLog("ready").WithPollInterval(10*time.Millisecond),
+	)
+
+	report, err := WaitUntilReadyWithReport(context.Background(), wg, target)
+	require.NoError(t, err)
+	assert.Equal(t, "*wait.MultiStrategy", report.Strategy)
+	// one attempt for the first strategy, two for the second one
+	assert.Equal(t, 3, report.Attempts)
+	assert.Equal(t, `log "ready"`, report.Detail)
+}
+
+func TestWaitUntilReadyWithReportFails(t *testing.T) {
+	target := &MockStrategyTarget{
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: false, Status: "exited", ExitCode: 1}, nil
+		},
+		LogsImpl: func(_ context.Context) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("")), nil
+		},
+	}
+
+	report, err := WaitUntilReadyWithReport(context.Background(), ForExit().WithExitCode(0), target)
+	require.Error(t, err)
+	assert.False(t, report.Ready())
+	assert.Equal(t, err, report.Err)
+
+	var exitErr *ExitError
+	assert.True(t, errors.As(report.Err, &exitErr))
+	assert.Equal(t, 1, report.Attempts)
+	assert.True(t, strings.HasPrefix(report.String(), "not ready after "))
+	assert.Contains(t, report.String(), "and 1 attempt (exit code 1): container exited with unexpected code 1")
+}
+
+func TestReadinessReportString(t *testing.T) {
+	start := time.Now()
+
+	report := &ReadinessReport{
+		Strategy: "*wait.HTTPStrategy",
+		Start:    start,
+		End:      start.Add(4237 * time.Millisecond),
+		Attempts: 37,
+		Detail:   "http GET /health 200",
+	}
+	assert.Equal(t, "ready in 4.2s after 37 attempts (http GET /health 200)", report.String())
+
+	report.End = start.Add(1500 * time.Microsecond)
+	report.Attempts = 1
+	report.Detail = ""
+	assert.Equal(t, "ready in 2ms after 1 attempt (*wait.HTTPStrategy)", report.String())
+}
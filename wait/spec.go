@@ -0,0 +1,693 @@
+package wait
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"gopkg.in/yaml.v3"
+)
+
+// SpecError is returned by FromSpec when a spec is invalid, pointing at the offending field
+type SpecError struct {
+	// Field is the path of the field, e.g. "strategies[1].status", empty for the whole spec
+	Field string
+	// Line is the line of the field in the spec, 0 if unknown
+	Line int
+	Err  error
+}
+
+func (e *SpecError) Error() string {
+	msg := e.Err.Error()
+	if e.Field != "" {
+		msg = e.Field + ": " + msg
+	}
+	if e.Line > 0 {
+		msg = fmt.Sprintf("line %d: %s", e.Line, msg)
+	}
+	return msg
+}
+
+func (e *SpecError) Unwrap() error {
+	return e.Err
+}
+
+// specParsers builds the strategies for each type of the spec
+var specParsers map[string]func(o *specObject) (Strategy, error)
+
+func init() {
+	specParsers = map[string]func(o *specObject) (Strategy, error){
+		"all":    parseAllSpec,
+		"any":    parseAnySpec,
+		"exec":   parseExecSpec,
+		"health": parseHealthSpec,
+		"http":   parseHTTPSpec,
+		"log":    parseLogSpec,
+		"port":   parsePortSpec,
+		"sql":    parseSQLSpec,
+	}
+}
+
+// FromSpec builds a strategy from its declaration in YAML or JSON, which allows to define
+// the readiness of containers in configuration files. Each strategy is an object with a
+// type, the options of this type, and the optional timeout and pollInterval durations.
+//
+// For Example:
+//
+//	type: all
+//	strategies:
+//	  - type: log
+//	    message: "database system is ready to accept connections"
+//	    occurrence: 2
+//	  - type: sql
+//	    port: 5432/tcp
+//	    driver: postgres
+//	    url: "postgres://postgres:password@{{.Host}}:{{.Port}}/postgres?sslmode=disable"
+//	    timeout: 30s
+//
+// An invalid spec returns a *SpecError pointing at the offending field.
+func FromSpec(data []byte) (Strategy, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, &SpecError{Err: err}
+	}
+
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, &SpecError{Err: errors.New("empty spec")}
+	}
+
+	return parseStrategySpec(doc.Content[0], "")
+}
+
+// parseStrategySpec builds the strategy declared by the node at the given path
+func parseStrategySpec(node *yaml.Node, path string) (Strategy, error) {
+	o, err := newSpecObject(node, path)
+	if err != nil {
+		return nil, err
+	}
+
+	o.typed = true
+
+	var typ string
+	if err := o.decode("type", &typ, true); err != nil {
+		return nil, err
+	}
+
+	parse, ok := specParsers[typ]
+	if !ok {
+		types := make([]string, 0, len(specParsers))
+		for t := range specParsers {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		return nil, o.errorf("type", "unknown type %q, must be one of %s", typ, strings.Join(types, ", "))
+	}
+
+	return parse(o)
+}
+
+func parseAllSpec(o *specObject) (Strategy, error) {
+	if err := o.allow("strategies", "deadline", "timeout"); err != nil {
+		return nil, err
+	}
+
+	strategies, err := o.strategies("strategies")
+	if err != nil {
+		return nil, err
+	}
+
+	ws := ForAll(strategies...)
+	if d, err := o.duration("deadline"); err != nil {
+		return nil, err
+	} else if d != nil {
+		ws.WithDeadline(*d)
+	}
+	if d, err := o.duration("timeout"); err != nil {
+		return nil, err
+	} else if d != nil {
+		ws.WithStartupTimeoutDefault(*d)
+	}
+
+	return ws, nil
+}
+
+func parseAnySpec(o *specObject) (Strategy, error) {
+	if err := o.allow("strategies", "deadline", "timeout"); err != nil {
+		return nil, err
+	}
+
+	strategies, err := o.strategies("strategies")
+	if err != nil {
+		return nil, err
+	}
+
+	ws := ForAny(strategies...)
+	if d, err := o.duration("deadline"); err != nil {
+		return nil, err
+	} else if d != nil {
+		ws.WithDeadline(*d)
+	}
+	if d, err := o.duration("timeout"); err != nil {
+		return nil, err
+	} else if d != nil {
+		ws.WithStartupTimeoutDefault(*d)
+	}
+
+	return ws, nil
+}
+
+func parseExecSpec(o *specObject) (Strategy, error) {
+	if err := o.allow("command", "exitCode", "timeout", "pollInterval"); err != nil {
+		return nil, err
+	}
+
+	var command []string
+	if err := o.decode("command", &command, true); err != nil {
+		return nil, err
+	}
+	if len(command) == 0 {
+		return nil, o.errorf("command", "must not be empty")
+	}
+
+	var exitCode int
+	if err := o.decode("exitCode", &exitCode, false); err != nil {
+		return nil, err
+	}
+
+	ws := ForExec(command).WithExitCodeMatcher(func(code int) bool {
+		return code == exitCode
+	})
+
+	timeout, pollInterval, err := o.timings()
+	if err != nil {
+		return nil, err
+	}
+	if timeout != nil {
+		ws.WithStartupTimeout(*timeout)
+	}
+	if pollInterval != nil {
+		ws.WithPollInterval(*pollInterval)
+	}
+
+	return ws, nil
+}
+
+func parseHealthSpec(o *specObject) (Strategy, error) {
+	if err := o.allow("timeout", "pollInterval"); err != nil {
+		return nil, err
+	}
+
+	ws := ForHealthCheck()
+
+	timeout, pollInterval, err := o.timings()
+	if err != nil {
+		return nil, err
+	}
+	if timeout != nil {
+		ws.WithStartupTimeout(*timeout)
+	}
+	if pollInterval != nil {
+		ws.WithPollInterval(*pollInterval)
+	}
+
+	return ws, nil
+}
+
+func parseHTTPSpec(o *specObject) (Strategy, error) {
+	if err := o.allow("path", "port", "method", "status", "headers", "body", "tls", "insecure", "timeout", "pollInterval"); err != nil {
+		return nil, err
+	}
+
+	var path string
+	if err := o.decode("path", &path, true); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, o.errorf("path", "must start with /")
+	}
+
+	ws := ForHTTP(path)
+
+	if o.has("port") {
+		port, err := o.port("port")
+		if err != nil {
+			return nil, err
+		}
+		ws.WithPort(port)
+	}
+
+	var method string
+	if err := o.decode("method", &method, false); err != nil {
+		return nil, err
+	}
+	if method != "" {
+		ws.WithMethod(strings.ToUpper(method))
+	}
+
+	if o.has("status") {
+		var status int
+		if err := o.decode("status", &status, true); err != nil {
+			return nil, err
+		}
+		if status < 100 || status > 599 {
+			return nil, o.errorf("status", "must be between 100 and 599")
+		}
+		ws.WithStatusCodeMatcher(func(code int) bool {
+			return code == status
+		})
+	}
+
+	var headers map[string]string
+	if err := o.decode("headers", &headers, false); err != nil {
+		return nil, err
+	}
+	if len(headers) > 0 {
+		ws.WithHeaders(headers)
+	}
+
+	if o.has("body") {
+		if err := parseHTTPBodySpec(o, ws); err != nil {
+			return nil, err
+		}
+	}
+
+	var useTLS, insecure bool
+	if err := o.decode("tls", &useTLS, false); err != nil {
+		return nil, err
+	}
+	if err := o.decode("insecure", &insecure, false); err != nil {
+		return nil, err
+	}
+	ws.WithTLS(useTLS).WithAllowInsecure(insecure)
+
+	timeout, pollInterval, err := o.timings()
+	if err != nil {
+		return nil, err
+	}
+	if timeout != nil {
+		ws.WithStartupTimeout(*timeout)
+	}
+	if pollInterval != nil {
+		ws.WithPollInterval(*pollInterval)
+	}
+
+	return ws, nil
+}
+
+// parseHTTPBodySpec adds the matchers of the body field of an HTTP spec
+func parseHTTPBodySpec(o *specObject, ws *HTTPStrategy) error {
+	body, err := o.object("body")
+	if err != nil {
+		return err
+	}
+	if err := body.allow("contains", "regexp", "json"); err != nil {
+		return err
+	}
+
+	var contains, expr string
+	if err := body.decode("contains", &contains, false); err != nil {
+		return err
+	}
+	if err := body.decode("regexp", &expr, false); err != nil {
+		return err
+	}
+
+	var re *regexp.Regexp
+	if expr != "" {
+		if re, err = regexp.Compile(expr); err != nil {
+			return body.errorf("regexp", "%w", err)
+		}
+	}
+
+	if contains != "" || re != nil {
+		ws.WithResponseMatcher(func(r io.Reader) bool {
+			b, err := io.ReadAll(r)
+			if err != nil {
+				return false
+			}
+			if contains != "" && !strings.Contains(string(b), contains) {
+				return false
+			}
+			return re == nil || re.Match(b)
+		})
+	}
+
+	var matchers []string
+	if err := body.decode("json", &matchers, false); err != nil {
+		return err
+	}
+	for i, m := range matchers {
+		if _, err := parseJSONExpression(m); err != nil {
+			return &SpecError{Field: body.path("json") + "[" + strconv.Itoa(i) + "]", Line: body.line("json"), Err: err}
+		}
+		ws.WithJSONMatcher(m)
+	}
+
+	return nil
+}
+
+func parseLogSpec(o *specObject) (Strategy, error) {
+	if err := o.allow("message", "regexp", "occurrence", "timeout", "pollInterval"); err != nil {
+		return nil, err
+	}
+
+	var message string
+	if err := o.decode("message", &message, true); err != nil {
+		return nil, err
+	}
+	if message == "" {
+		return nil, o.errorf("message", "must not be empty")
+	}
+
+	ws := ForLog(message)
+
+	var isRegexp bool
+	if err := o.decode("regexp", &isRegexp, false); err != nil {
+		return nil, err
+	}
+	if isRegexp {
+		if _, err := regexp.Compile(message); err != nil {
+			return nil, o.errorf("message", "%w", err)
+		}
+		ws.AsRegexp()
+	}
+
+	if o.has("occurrence") {
+		var occurrence int
+		if err := o.decode("occurrence", &occurrence, true); err != nil {
+			return nil, err
+		}
+		if occurrence < 1 {
+			return nil, o.errorf("occurrence", "must be at least 1")
+		}
+		ws.WithOccurrence(occurrence)
+	}
+
+	timeout, pollInterval, err := o.timings()
+	if err != nil {
+		return nil, err
+	}
+	if timeout != nil {
+		ws.WithStartupTimeout(*timeout)
+	}
+	if pollInterval != nil {
+		ws.WithPollInterval(*pollInterval)
+	}
+
+	return ws, nil
+}
+
+func parsePortSpec(o *specObject) (Strategy, error) {
+	if err := o.allow("port", "timeout", "pollInterval"); err != nil {
+		return nil, err
+	}
+
+	// without port, any of the exposed ports is used, as ForExposedPort doesn't choose one in particular
+	ws := ForExposedPort()
+	if o.has("port") {
+		port, err := o.port("port")
+		if err != nil {
+			return nil, err
+		}
+		ws = ForListeningPort(port)
+	}
+
+	timeout, pollInterval, err := o.timings()
+	if err != nil {
+		return nil, err
+	}
+	if timeout != nil {
+		ws.WithStartupTimeout(*timeout)
+	}
+	if pollInterval != nil {
+		ws.WithPollInterval(*pollInterval)
+	}
+
+	return ws, nil
+}
+
+// sqlURLData is the data available to the URL template of an SQL spec
+type sqlURLData struct {
+	Host string
+	Port string
+}
+
+func parseSQLSpec(o *specObject) (Strategy, error) {
+	if err := o.allow("port", "driver", "url", "query", "timeout", "pollInterval"); err != nil {
+		return nil, err
+	}
+
+	port, err := o.port("port")
+	if err != nil {
+		return nil, err
+	}
+
+	var driver string
+	if err := o.decode("driver", &driver, true); err != nil {
+		return nil, err
+	}
+	if driver == "" {
+		return nil, o.errorf("driver", "must not be empty")
+	}
+
+	var url string
+	if err := o.decode("url", &url, true); err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New("url").Option("missingkey=error").Parse(url)
+	if err != nil {
+		return nil, o.errorf("url", "%w", err)
+	}
+	// references to unknown fields are only detected when executing the template
+	if err := tmpl.Execute(io.Discard, sqlURLData{Host: "localhost", Port: port.Port()}); err != nil {
+		return nil, o.errorf("url", "%w", err)
+	}
+
+	ws := ForSQL(port, driver, func(host string, port nat.Port) string {
+		var sb strings.Builder
+		_ = tmpl.Execute(&sb, sqlURLData{Host: host, Port: port.Port()})
+		return sb.String()
+	})
+
+	var query string
+	if err := o.decode("query", &query, false); err != nil {
+		return nil, err
+	}
+	if query != "" {
+		ws.WithQuery(query)
+	}
+
+	timeout, pollInterval, err := o.timings()
+	if err != nil {
+		return nil, err
+	}
+	if timeout != nil {
+		ws.WithStartupTimeout(*timeout)
+	}
+	if pollInterval != nil {
+		ws.WithPollInterval(*pollInterval)
+	}
+
+	return ws, nil
+}
+
+// specObject is an object of the spec, whose fields are decoded one by one
+// so that errors point at the offending field
+type specObject struct {
+	node   *yaml.Node
+	prefix string
+	// typed objects declare a strategy, with its type
+	typed  bool
+	keys   map[string]*yaml.Node
+	values map[string]*yaml.Node
+}
+
+func newSpecObject(node *yaml.Node, path string) (*specObject, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, &SpecError{Field: path, Line: node.Line, Err: errors.New("must be an object")}
+	}
+
+	o := &specObject{
+		node:   node,
+		prefix: path,
+		keys:   make(map[string]*yaml.Node),
+		values: make(map[string]*yaml.Node),
+	}
+	if path != "" {
+		o.prefix += "."
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if _, ok := o.keys[key.Value]; ok {
+			return nil, &SpecError{Field: o.path(key.Value), Line: key.Line, Err: errors.New("duplicate field")}
+		}
+		o.keys[key.Value] = key
+		o.values[key.Value] = value
+	}
+
+	return o, nil
+}
+
+// path returns the path of the given field
+func (o *specObject) path(name string) string {
+	return o.prefix + name
+}
+
+// line returns the line of the given field, or of the object if the field is not defined
+func (o *specObject) line(name string) int {
+	if key, ok := o.keys[name]; ok {
+		return key.Line
+	}
+	return o.node.Line
+}
+
+func (o *specObject) errorf(name string, format string, args ...interface{}) error {
+	return &SpecError{Field: o.path(name), Line: o.line(name), Err: fmt.Errorf(format, args...)}
+}
+
+func (o *specObject) has(name string) bool {
+	_, ok := o.values[name]
+	return ok
+}
+
+// allow checks that the object only defines the given fields, besides the type of strategies
+func (o *specObject) allow(names ...string) error {
+	allowed := map[string]bool{"type": o.typed}
+	for _, name := range names {
+		allowed[name] = true
+	}
+
+	for i := 0; i < len(o.node.Content); i += 2 {
+		if key := o.node.Content[i]; !allowed[key.Value] {
+			return o.errorf(key.Value, "unknown field")
+		}
+	}
+
+	return nil
+}
+
+// decode decodes the given field into out, leaving it untouched if the field is not defined
+func (o *specObject) decode(name string, out interface{}, required bool) error {
+	value, ok := o.values[name]
+	if !ok || value.Tag == "!!null" {
+		if required {
+			return o.errorf(name, "required")
+		}
+		return nil
+	}
+
+	var expected string
+	switch out.(type) {
+	case *string:
+		expected = "a string"
+		if value.Kind != yaml.ScalarNode {
+			return o.errorf(name, "must be %s", expected)
+		}
+	case *int:
+		expected = "an integer"
+	case *bool:
+		expected = "a boolean"
+	case *[]string:
+		expected = "a list of strings"
+	case *map[string]string:
+		expected = "an object of strings"
+	default:
+		expected = fmt.Sprintf("%T", out)
+	}
+
+	if err := value.Decode(out); err != nil {
+		return o.errorf(name, "must be %s", expected)
+	}
+
+	return nil
+}
+
+// duration decodes the given field as a duration such as "1m30s", nil if not defined
+func (o *specObject) duration(name string) (*time.Duration, error) {
+	var s string
+	if err := o.decode(name, &s, false); err != nil || s == "" {
+		return nil, err
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return nil, o.errorf(name, "invalid duration %q", s)
+	}
+	if d <= 0 {
+		return nil, o.errorf(name, "must be positive")
+	}
+
+	return &d, nil
+}
+
+// timings decodes the timeout and pollInterval fields shared by the strategies
+func (o *specObject) timings() (timeout *time.Duration, pollInterval *time.Duration, err error) {
+	if timeout, err = o.duration("timeout"); err != nil {
+		return nil, nil, err
+	}
+	if pollInterval, err = o.duration("pollInterval"); err != nil {
+		return nil, nil, err
+	}
+	return timeout, pollInterval, nil
+}
+
+// port decodes the required given field as a port such as "8080/tcp", using tcp if the protocol is omitted
+func (o *specObject) port(name string) (nat.Port, error) {
+	var s string
+	if err := o.decode(name, &s, true); err != nil {
+		return "", err
+	}
+
+	proto, port := nat.SplitProtoPort(s)
+	if proto != "tcp" && proto != "udp" && proto != "sctp" {
+		return "", o.errorf(name, "invalid protocol %q in port %q", proto, s)
+	}
+
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return "", o.errorf(name, "invalid port %q", s)
+	}
+
+	return nat.Port(port + "/" + proto), nil
+}
+
+// object decodes the given field as a nested object
+func (o *specObject) object(name string) (*specObject, error) {
+	value, ok := o.values[name]
+	if !ok {
+		return nil, o.errorf(name, "required")
+	}
+	return newSpecObject(value, o.path(name))
+}
+
+// strategies decodes the given field as a non-empty list of strategies
+func (o *specObject) strategies(name string) ([]Strategy, error) {
+	value, ok := o.values[name]
+	if !ok {
+		return nil, o.errorf(name, "required")
+	}
+	if value.Kind != yaml.SequenceNode {
+		return nil, o.errorf(name, "must be a list of strategies")
+	}
+	if len(value.Content) == 0 {
+		return nil, o.errorf(name, "must not be empty")
+	}
+
+	strategies := make([]Strategy, 0, len(value.Content))
+	for i, node := range value.Content {
+		ws, err := parseStrategySpec(node, fmt.Sprintf("%s[%d]", o.path(name), i))
+		if err != nil {
+			return nil, err
+		}
+		strategies = append(strategies, ws)
+	}
+
+	return strategies, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/docker/go-connections/nat"
@@ -14,7 +15,10 @@ var (
 	_ StrategyTimeout = (*waitForSql)(nil)
 )
 
-const defaultForSqlQuery = "SELECT 1"
+const (
+	defaultForSqlQuery        = "SELECT 1"
+	defaultForSqlQueryTimeout = 5 * time.Second
+)
 
 // ForSQL constructs a new waitForSql strategy for the given driver
 func ForSQL(port nat.Port, driver string, url func(host string, port nat.Port) string) *waitForSql {
@@ -25,11 +29,17 @@ func ForSQL(port nat.Port, driver string, url func(host string, port nat.Port) s
 		startupTimeout: defaultStartupTimeout(),
 		PollInterval:   defaultPollInterval(),
 		query:          defaultForSqlQuery,
+		queryTimeout:   defaultForSqlQueryTimeout,
 	}
 }
 
+// sqlCheck is an additional condition the database must meet after the query succeeds
+type sqlCheck func(ctx context.Context, db *sql.DB) error
+
 type waitForSql struct {
 	timeout *time.Duration
+	// backoff defines the intervals between attempts, overriding the poll interval
+	backoff Backoff
 
 	URL            func(host string, port nat.Port) string
 	Driver         string
@@ -37,6 +47,8 @@ type waitForSql struct {
 	startupTimeout time.Duration
 	PollInterval   time.Duration
 	query          string
+	queryTimeout   time.Duration
+	checks         []sqlCheck
 }
 
 // WithStartupTimeout can be used to change the default startup timeout
@@ -51,19 +63,100 @@ func (w *waitForSql) WithPollInterval(pollInterval time.Duration) *waitForSql {
 	return w
 }
 
+// WithBackoff can be used to replace the fixed poll interval by a backoff policy,
+// such as an exponential backoff for slow starting services
+func (w *waitForSql) WithBackoff(b Backoff) *waitForSql {
+	w.backoff = b
+	return w
+}
+
 // WithQuery can be used to override the default query used in the strategy.
 func (w *waitForSql) WithQuery(query string) *waitForSql {
 	w.query = query
 	return w
 }
 
+// WithQueryTimeout can be used to override the default timeout of 5 seconds for each attempt,
+// including opening the connection, so a hung connection does not consume the whole startup timeout.
+func (w *waitForSql) WithQueryTimeout(timeout time.Duration) *waitForSql {
+	w.queryTimeout = timeout
+	return w
+}
+
+// WithSchema can be used to wait until the given schema exists, as listed in information_schema.schemata,
+// and the given tables exist in it, see WithTable. In MySQL and MariaDB, schemas are databases.
+func (w *waitForSql) WithSchema(schema string, tables ...string) *waitForSql {
+	query := "SELECT COUNT(*) FROM information_schema.schemata WHERE schema_name = " + quoteSQLString(schema)
+
+	w.checks = append(w.checks, func(ctx context.Context, db *sql.DB) error {
+		var count int
+		if err := db.QueryRowContext(ctx, query).Scan(&count); err != nil {
+			return fmt.Errorf("schema %q: %w", schema, err)
+		}
+		if count == 0 {
+			return fmt.Errorf("schema %q does not exist", schema)
+		}
+		return nil
+	})
+
+	for _, table := range tables {
+		w.WithTable(schema + "." + table)
+	}
+	return w
+}
+
+// WithTable can be used to wait until the given table exists, e.g. after the init scripts
+// of the container are run. The table name is used as is in the query, so it can be qualified
+// with its schema, e.g. "public.users".
+func (w *waitForSql) WithTable(table string) *waitForSql {
+	query := "SELECT 1 FROM " + table + " WHERE 1 = 0"
+
+	w.checks = append(w.checks, func(ctx context.Context, db *sql.DB) error {
+		rows, err := db.QueryContext(ctx, query)
+		if err != nil {
+			return fmt.Errorf("table %q: %w", table, err)
+		}
+		defer rows.Close()
+		return rows.Err()
+	})
+	return w
+}
+
+// WithRowCount can be used to wait until the given query returns the expected number of rows,
+// e.g. until some data has been loaded.
+func (w *waitForSql) WithRowCount(query string, expected int) *waitForSql {
+	w.checks = append(w.checks, func(ctx context.Context, db *sql.DB) error {
+		rows, err := db.QueryContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		count := 0
+		for rows.Next() {
+			count++
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if count != expected {
+			return fmt.Errorf("query %q returned %d rows, expected %d", query, count, expected)
+		}
+		return nil
+	})
+	return w
+}
+
 func (w *waitForSql) Timeout() *time.Duration {
 	return w.timeout
 }
 
-// WaitUntilReady repeatedly tries to run "SELECT 1" or user defined query on the given port using sql and driver.
+// WaitUntilReady repeatedly tries to run "SELECT 1" or user defined query on the given port using sql and driver,
+// followed by the schema, table and row count checks, if any.
 //
-// If it doesn't succeed until the timeout value which defaults to 60 seconds, it will return an error.
+// If it doesn't succeed until the timeout value which defaults to 60 seconds, it will return an error,
+// including the last error returned by the driver.
 func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
 	timeout := defaultStartupTimeout()
 	if w.timeout != nil {
@@ -78,8 +171,7 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 		return err
 	}
 
-	ticker := time.NewTicker(w.PollInterval)
-	defer ticker.Stop()
+	poll := pollBackoff(ctx, w.backoff, w.PollInterval)
 
 	var port nat.Port
 	port, err = target.MappedPort(ctx, w.Port)
@@ -88,7 +180,7 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 		select {
 		case <-ctx.Done():
 			return fmt.Errorf("%w: %w", ctx.Err(), err)
-		case <-ticker.C:
+		case <-time.After(nextInterval(poll)):
 			if err := checkTarget(ctx, target); err != nil {
 				return err
 			}
@@ -101,18 +193,48 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 		return fmt.Errorf("sql.Open: %w", err)
 	}
 	defer db.Close()
+
+	var lastErr error
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
-		case <-ticker.C:
+			if lastErr == nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("%w: %w", ctx.Err(), lastErr)
+		case <-time.After(nextInterval(poll)):
 			if err := checkTarget(ctx, target); err != nil {
 				return err
 			}
-			if _, err := db.ExecContext(ctx, w.query); err != nil {
-				continue
+			recordAttempt(ctx)
+			if lastErr = w.check(ctx, db); lastErr == nil {
+				recordDetail(ctx, "sql %s", w.query)
+				return nil
 			}
-			return nil
 		}
 	}
 }
+
+// check runs the query and the additional checks, limited by the query timeout.
+// The connections of the db are reused between attempts.
+func (w *waitForSql) check(ctx context.Context, db *sql.DB) error {
+	ctx, cancel := context.WithTimeout(ctx, w.queryTimeout)
+	defer cancel()
+
+	if _, err := db.ExecContext(ctx, w.query); err != nil {
+		return err
+	}
+
+	for _, check := range w.checks {
+		if err := check(ctx, db); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// quoteSQLString returns the given value as a SQL string literal
+func quoteSQLString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
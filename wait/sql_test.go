@@ -4,11 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_waitForSql_WithQuery(t *testing.T) {
@@ -36,6 +42,7 @@ func Test_waitForSql_WithQuery(t *testing.T) {
 
 func init() {
 	sql.Register("mock", &mockSQLDriver{})
+	sql.Register("mockquery", &mockQueryDriver{})
 }
 
 type mockSQLDriver struct {
@@ -315,3 +322,228 @@ func TestWaitForSQLFailsWhileQueryExecutingDueToUnexpectedContainerStatus(t *tes
 		}
 	}
 }
+
+// mockQueryHandlers holds the handlers of the mockquery driver, by data source name
+var mockQueryHandlers sync.Map
+
+// mockQueryHandler returns the rows of a query, each row having a single column
+type mockQueryHandler func(ctx context.Context, query string) ([]driver.Value, error)
+
+// mockQueryDriver is a driver which answers the queries with the handler registered for the data source name
+type mockQueryDriver struct{}
+
+func (d *mockQueryDriver) Open(name string) (driver.Conn, error) {
+	handler, ok := mockQueryHandlers.Load(name)
+	if !ok {
+		return nil, errors.New("no handler for " + name)
+	}
+	return &mockQueryConn{handler: handler.(mockQueryHandler)}, nil
+}
+
+type mockQueryConn struct {
+	handler mockQueryHandler
+}
+
+func (c *mockQueryConn) Prepare(_ string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *mockQueryConn) Close() error {
+	return nil
+}
+
+func (c *mockQueryConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (c *mockQueryConn) ExecContext(ctx context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if _, err := c.handler(ctx, query); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (c *mockQueryConn) QueryContext(ctx context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	values, err := c.handler(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &mockQueryRows{values: values}, nil
+}
+
+type mockQueryRows struct {
+	values []driver.Value
+}
+
+func (r *mockQueryRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (r *mockQueryRows) Close() error {
+	return nil
+}
+
+func (r *mockQueryRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0] = r.values[0]
+	r.values = r.values[1:]
+	return nil
+}
+
+func registerMockQueryHandler(t *testing.T, handler mockQueryHandler) string {
+	t.Helper()
+
+	name := t.Name()
+	mockQueryHandlers.Store(name, handler)
+	t.Cleanup(func() { mockQueryHandlers.Delete(name) })
+
+	return name
+}
+
+func TestWaitForSQLTimesOutReportingLastError(t *testing.T) {
+	dsn := registerMockQueryHandler(t, func(_ context.Context, _ string) ([]driver.Value, error) {
+		return nil, errors.New("the database system is starting up")
+	})
+
+	wg := ForSQL("5432/tcp", "mockquery", func(_ string, _ nat.Port) string { return dsn }).
+		WithStartupTimeout(500 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), newRunningStrategyTarget("localhost", "49152"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), "the database system is starting up")
+}
+
+func TestWaitForSQLWithQueryTimeout(t *testing.T) {
+	var mtx sync.Mutex
+	var attempts int
+	dsn := registerMockQueryHandler(t, func(ctx context.Context, _ string) ([]driver.Value, error) {
+		mtx.Lock()
+		attempts++
+		hung := attempts == 1
+		mtx.Unlock()
+
+		if hung {
+			// the first attempt hangs until it is cancelled
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return nil, nil
+	})
+
+	wg := ForSQL("5432/tcp", "mockquery", func(_ string, _ nat.Port) string { return dsn }).
+		WithQueryTimeout(100 * time.Millisecond).
+		WithStartupTimeout(time.Second)
+
+	err := wg.WaitUntilReady(context.Background(), newRunningStrategyTarget("localhost", "49152"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestWaitForSQLWithSchemaAndTable(t *testing.T) {
+	var mtx sync.Mutex
+	var migrated bool
+	var queries []string
+	dsn := registerMockQueryHandler(t, func(_ context.Context, query string) ([]driver.Value, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		queries = append(queries, query)
+		switch query {
+		case "SELECT 1":
+			return nil, nil
+		case "SELECT COUNT(*) FROM information_schema.schemata WHERE schema_name = 'app''s'":
+			return []driver.Value{int64(1)}, nil
+		case "SELECT 1 FROM app.users WHERE 1 = 0":
+			if !migrated {
+				// the table is created by the next attempt
+				migrated = true
+				return nil, errors.New(`relation "app.users" does not exist`)
+			}
+			return nil, nil
+		default:
+			return nil, errors.New("unexpected query: " + query)
+		}
+	})
+
+	wg := ForSQL("5432/tcp", "mockquery", func(_ string, _ nat.Port) string { return dsn }).
+		WithSchema("app's").
+		WithTable("app.users").
+		WithStartupTimeout(time.Second)
+
+	err := wg.WaitUntilReady(context.Background(), newRunningStrategyTarget("localhost", "49152"))
+	require.NoError(t, err)
+	assert.Len(t, queries, 6)
+}
+
+func TestWaitForSQLWithSchemaTables(t *testing.T) {
+	var mtx sync.Mutex
+	var queries []string
+	dsn := registerMockQueryHandler(t, func(_ context.Context, query string) ([]driver.Value, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		queries = append(queries, query)
+		if strings.HasPrefix(query, "SELECT COUNT(*)") {
+			return []driver.Value{int64(1)}, nil
+		}
+		return nil, nil
+	})
+
+	wg := ForSQL("5432/tcp", "mockquery", func(_ string, _ nat.Port) string { return dsn }).
+		WithSchema("app", "users", "orders").
+		WithStartupTimeout(time.Second)
+
+	err := wg.WaitUntilReady(context.Background(), newRunningStrategyTarget("localhost", "49152"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"SELECT 1",
+		"SELECT COUNT(*) FROM information_schema.schemata WHERE schema_name = 'app'",
+		"SELECT 1 FROM app.users WHERE 1 = 0",
+		"SELECT 1 FROM app.orders WHERE 1 = 0",
+	}, queries)
+}
+
+func TestWaitForSQLWithSchemaTimesOut(t *testing.T) {
+	dsn := registerMockQueryHandler(t, func(_ context.Context, query string) ([]driver.Value, error) {
+		if query == "SELECT 1" {
+			return nil, nil
+		}
+		return []driver.Value{int64(0)}, nil
+	})
+
+	wg := ForSQL("5432/tcp", "mockquery", func(_ string, _ nat.Port) string { return dsn }).
+		WithSchema("app").
+		WithStartupTimeout(500 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), newRunningStrategyTarget("localhost", "49152"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), `schema "app" does not exist`)
+}
+
+func TestWaitForSQLWithRowCount(t *testing.T) {
+	var mtx sync.Mutex
+	var loaded []driver.Value
+	dsn := registerMockQueryHandler(t, func(_ context.Context, query string) ([]driver.Value, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		if query == "SELECT 1" {
+			return nil, nil
+		}
+		// a row is loaded on every attempt
+		loaded = append(loaded, int64(len(loaded)))
+		return loaded, nil
+	})
+
+	wg := ForSQL("5432/tcp", "mockquery", func(_ string, _ nat.Port) string { return dsn }).
+		WithRowCount("SELECT id FROM users", 3).
+		WithStartupTimeout(time.Second)
+
+	err := wg.WaitUntilReady(context.Background(), newRunningStrategyTarget("localhost", "49152"))
+	require.NoError(t, err)
+	assert.Len(t, loaded, 3)
+}
@@ -0,0 +1,264 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*TCPStrategy)(nil)
+	_ StrategyTimeout = (*TCPStrategy)(nil)
+)
+
+// TCPStep is a single exchange in the conversation of a TCPStrategy.
+// The Send payload, if any, is written first, and then the response is read
+// until the Expect matcher, if any, returns true.
+type TCPStep struct {
+	Send   []byte
+	Expect func(response []byte) bool
+}
+
+// TCPStrategy will wait until a TCP conversation with the container succeeds,
+// which is useful for line protocols where an open port does not mean the
+// service is ready to process requests.
+type TCPStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+	// backoff defines the intervals between attempts, overriding the poll interval
+	backoff Backoff
+
+	// additional properties
+	Port         nat.Port
+	Steps        []TCPStep
+	UseTLS       bool
+	TLSConfig    *tls.Config // TLS config for the connection, InsecureSkipVerify is used if nil
+	ReadTimeout  time.Duration
+	PollInterval time.Duration
+}
+
+// NewTCPStrategy constructs a TCP strategy for the given port, with polling interval
+// of 100 milliseconds and startup timeout of 60 seconds by default
+func NewTCPStrategy(port nat.Port) *TCPStrategy {
+	return &TCPStrategy{
+		Port:         port,
+		ReadTimeout:  time.Second,
+		PollInterval: defaultPollInterval(),
+	}
+}
+
+// fluent builders for each property
+// since go has neither covariance nor generics, the return type must be the type of the concrete implementation
+// this is true for all properties, even the "shared" ones like startupTimeout
+
+// ForTCP is the default construction for the fluid interface.
+//
+// For Example:
+//
+//	wait.
+//		ForTCP("6379/tcp").
+//		Send([]byte("PING\r\n")).
+//		Expect(wait.ContainsBytes([]byte("+PONG")))
+func ForTCP(port nat.Port) *TCPStrategy {
+	return NewTCPStrategy(port)
+}
+
+// Send adds a new step to the conversation, writing the given payload to the connection
+func (ws *TCPStrategy) Send(payload []byte) *TCPStrategy {
+	ws.Steps = append(ws.Steps, TCPStep{Send: payload})
+	return ws
+}
+
+// Expect sets the matcher for the response of the last step. If there are no steps,
+// or the last one already has a matcher, a new step without payload is added, which
+// allows to wait for banners sent by the server when the connection is opened.
+func (ws *TCPStrategy) Expect(matcher func(response []byte) bool) *TCPStrategy {
+	if len(ws.Steps) == 0 || ws.Steps[len(ws.Steps)-1].Expect != nil {
+		ws.Steps = append(ws.Steps, TCPStep{})
+	}
+	ws.Steps[len(ws.Steps)-1].Expect = matcher
+	return ws
+}
+
+// WithTLS can be used to run the conversation over TLS
+func (ws *TCPStrategy) WithTLS(useTLS bool, tlsconf ...*tls.Config) *TCPStrategy {
+	ws.UseTLS = useTLS
+	if useTLS && len(tlsconf) > 0 {
+		ws.TLSConfig = tlsconf[0]
+	}
+	return ws
+}
+
+// WithReadTimeout can be used to override the default timeout of 1 second to receive an expected response
+func (ws *TCPStrategy) WithReadTimeout(readTimeout time.Duration) *TCPStrategy {
+	ws.ReadTimeout = readTimeout
+	return ws
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (ws *TCPStrategy) WithStartupTimeout(startupTimeout time.Duration) *TCPStrategy {
+	ws.timeout = &startupTimeout
+	return ws
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ws *TCPStrategy) WithPollInterval(pollInterval time.Duration) *TCPStrategy {
+	ws.PollInterval = pollInterval
+	return ws
+}
+
+// WithBackoff can be used to replace the fixed poll interval by a backoff policy,
+// such as an exponential backoff for slow starting services
+func (ws *TCPStrategy) WithBackoff(b Backoff) *TCPStrategy {
+	ws.backoff = b
+	return ws
+}
+
+// ContainsBytes returns a matcher which checks that the response contains the given bytes
+func ContainsBytes(b []byte) func(response []byte) bool {
+	return func(response []byte) bool {
+		return bytes.Contains(response, b)
+	}
+}
+
+// HasPrefixBytes returns a matcher which checks that the response starts with the given bytes
+func HasPrefixBytes(b []byte) func(response []byte) bool {
+	return func(response []byte) bool {
+		return bytes.HasPrefix(response, b)
+	}
+}
+
+func (ws *TCPStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (ws *TCPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	timeout := defaultStartupTimeout()
+	if ws.timeout != nil {
+		timeout = *ws.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	poll := pollBackoff(ctx, ws.backoff, ws.PollInterval)
+
+	ipAddress, err := target.Host(ctx)
+	if err != nil {
+		return err
+	}
+
+	var port nat.Port
+	port, err = target.MappedPort(ctx, ws.Port)
+
+	for port == "" {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-time.After(nextInterval(poll)):
+			if err := checkTarget(ctx, target); err != nil {
+				return err
+			}
+			port, err = target.MappedPort(ctx, ws.Port)
+		}
+	}
+
+	if port.Proto() != "tcp" {
+		return errors.New("cannot use TCP strategy on non-TCP ports")
+	}
+
+	address := net.JoinHostPort(ipAddress, strconv.Itoa(port.Int()))
+
+	var lastErr error
+	for {
+		if err := checkTarget(ctx, target); err != nil {
+			return err
+		}
+
+		recordAttempt(ctx)
+		lastErr = ws.converse(ctx, address)
+		if lastErr == nil {
+			recordDetail(ctx, "tcp %s", ws.Port)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), lastErr)
+		case <-time.After(nextInterval(poll)):
+		}
+	}
+}
+
+// converse opens a connection to the address and runs all the steps on it
+func (ws *TCPStrategy) converse(ctx context.Context, address string) error {
+	netDialer := &net.Dialer{Timeout: time.Second}
+
+	var conn net.Conn
+	var err error
+	if ws.UseTLS {
+		tlsConfig := ws.TLSConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{InsecureSkipVerify: true}
+		}
+		dialer := &tls.Dialer{NetDialer: netDialer, Config: tlsConfig}
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = netDialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	for i, step := range ws.Steps {
+		if len(step.Send) > 0 {
+			if err := conn.SetWriteDeadline(time.Now().Add(ws.ReadTimeout)); err != nil {
+				return err
+			}
+			if _, err := conn.Write(step.Send); err != nil {
+				return fmt.Errorf("step %d: %w", i, err)
+			}
+		}
+
+		if step.Expect == nil {
+			continue
+		}
+
+		if err := expectResponse(conn, step.Expect, ws.ReadTimeout); err != nil {
+			return fmt.Errorf("step %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// expectResponse reads from the connection until the received bytes match,
+// or the read timeout is reached
+func expectResponse(conn net.Conn, matcher func([]byte) bool, readTimeout time.Duration) error {
+	if err := conn.SetReadDeadline(time.Now().Add(readTimeout)); err != nil {
+		return err
+	}
+
+	var response []byte
+	buf := make([]byte, 4096)
+	for {
+		n, err := conn.Read(buf)
+		response = append(response, buf[:n]...)
+		if n > 0 && matcher(response) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unexpected response %q: %w", response, err)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package wait
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLineServer starts a server which greets every client with the banner and then
+// answers each line using the handler, returning the port it listens on
+func newLineServer(t *testing.T, listener net.Listener, banner string, handler func(line string) string) nat.Port {
+	t.Helper()
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+
+				if banner != "" {
+					_, _ = conn.Write([]byte(banner))
+				}
+
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					_, _ = conn.Write([]byte(handler(scanner.Text())))
+				}
+			}(conn)
+		}
+	}()
+
+	rawPort := listener.Addr().(*net.TCPAddr).Port
+	port, err := nat.NewPort("tcp", strconv.Itoa(rawPort))
+	require.NoError(t, err)
+
+	return port
+}
+
+func TestWaitForTCPSucceeds(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	var pings int32
+	port := newLineServer(t, listener, "", func(line string) string {
+		if line != "PING" {
+			return "-ERR unknown command\r\n"
+		}
+		// the first pings are answered while the dataset is still loading
+		if atomic.AddInt32(&pings, 1) < 3 {
+			return "-LOADING Redis is loading the dataset in memory\r\n"
+		}
+		return "+PONG\r\n"
+	})
+
+	wg := ForTCP("6379/tcp").
+		Send([]byte("PING\r\n")).
+		Expect(ContainsBytes([]byte("+PONG"))).
+		WithStartupTimeout(5 * time.Second).
+		WithPollInterval(10 * time.Millisecond)
+
+	err = wg.WaitUntilReady(context.Background(), newRunningStrategyTarget("localhost", port))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&pings))
+}
+
+func TestWaitForTCPMultiStepSucceeds(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	port := newLineServer(t, listener, "INFO {\"server_id\":\"test\"}\r\n", func(line string) string {
+		switch {
+		case strings.HasPrefix(line, "CONNECT"):
+			return ""
+		case line == "PING":
+			return "PONG\r\n"
+		default:
+			return "-ERR 'Unknown Protocol Operation'\r\n"
+		}
+	})
+
+	wg := ForTCP("4222/tcp").
+		Expect(HasPrefixBytes([]byte("INFO "))).
+		Send([]byte("CONNECT {}\r\nPING\r\n")).
+		Expect(ContainsBytes([]byte("PONG"))).
+		WithStartupTimeout(5 * time.Second)
+
+	require.Len(t, wg.Steps, 2)
+
+	err = wg.WaitUntilReady(context.Background(), newRunningStrategyTarget("localhost", port))
+	require.NoError(t, err)
+}
+
+func TestWaitForTCPWithTLSSucceeds(t *testing.T) {
+	cert, err := tls.LoadX509KeyPair("testdata/tls.pem", "testdata/tls-key.pem")
+	require.NoError(t, err)
+
+	listener, err := tls.Listen("tcp", "localhost:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	require.NoError(t, err)
+
+	port := newLineServer(t, listener, "", func(line string) string {
+		return "+PONG\r\n"
+	})
+
+	wg := ForTCP("6379/tcp").
+		WithTLS(true).
+		Send([]byte("PING\r\n")).
+		Expect(ContainsBytes([]byte("+PONG"))).
+		WithStartupTimeout(5 * time.Second)
+
+	err = wg.WaitUntilReady(context.Background(), newRunningStrategyTarget("localhost", port))
+	require.NoError(t, err)
+}
+
+func TestWaitForTCPTimesOutReportingLastResponse(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	port := newLineServer(t, listener, "", func(line string) string {
+		return "-LOADING Redis is loading the dataset in memory\r\n"
+	})
+
+	wg := ForTCP("6379/tcp").
+		Send([]byte("PING\r\n")).
+		Expect(ContainsBytes([]byte("+PONG"))).
+		WithReadTimeout(100 * time.Millisecond).
+		WithStartupTimeout(500 * time.Millisecond)
+
+	err = wg.WaitUntilReady(context.Background(), newRunningStrategyTarget("localhost", port))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), "-LOADING")
+}
+
+func TestWaitForTCPFailsDueToExitedContainer(t *testing.T) {
+	target := newRunningStrategyTarget("localhost", "6379/tcp")
+	target.StateImpl = func(_ context.Context) (*types.ContainerState, error) {
+		return &types.ContainerState{Status: "exited", ExitCode: 1}, nil
+	}
+
+	wg := ForTCP("6379/tcp").WithStartupTimeout(500 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.EqualError(t, err, "container exited with code 1")
+}
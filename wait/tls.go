@@ -0,0 +1,270 @@
+package wait
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*TLSStrategy)(nil)
+	_ StrategyTimeout = (*TLSStrategy)(nil)
+)
+
+// TLSStrategy will wait until a TLS handshake can be completed against the given port
+type TLSStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+	// backoff defines the intervals between attempts, overriding the poll interval
+	backoff Backoff
+
+	// additional properties
+	Port nat.Port
+	// RootCAs is the pool used to verify the certificate chain presented by the container.
+	// If nil, the chain is not verified and any certificate is accepted.
+	RootCAs *x509.CertPool
+	// ServerName is the name used for SNI and, when RootCAs is set, for hostname verification.
+	ServerName string
+	// Subject is the expected common name of the leaf certificate, if not empty
+	Subject string
+	// SANs are the DNS names or IP addresses the leaf certificate must be valid for
+	SANs               []string
+	CertificateMatcher func(cert *x509.Certificate) bool
+	PollInterval       time.Duration
+
+	mtx              sync.Mutex
+	peerCertificates []*x509.Certificate
+}
+
+// NewTLSStrategy constructs a TLS strategy waiting on the given port
+func NewTLSStrategy(port nat.Port) *TLSStrategy {
+	return &TLSStrategy{
+		Port:         port,
+		PollInterval: defaultPollInterval(),
+	}
+}
+
+// fluent builders for each property
+// since go has neither covariance nor generics, the return type must be the type of the concrete implementation
+// this is true for all properties, even the "shared" ones like startupTimeout
+
+// ForTLS is the default construction for the fluid interface.
+//
+// For Example:
+//
+//	wait.
+//		ForTLS("9200/tcp").
+//		WithRootCAs(pool).
+//		WithSAN("localhost")
+func ForTLS(port nat.Port) *TLSStrategy {
+	return NewTLSStrategy(port)
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (ws *TLSStrategy) WithStartupTimeout(startupTimeout time.Duration) *TLSStrategy {
+	ws.timeout = &startupTimeout
+	return ws
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ws *TLSStrategy) WithPollInterval(pollInterval time.Duration) *TLSStrategy {
+	ws.PollInterval = pollInterval
+	return ws
+}
+
+// WithBackoff can be used to replace the fixed poll interval by a backoff policy,
+// such as an exponential backoff for slow starting services
+func (ws *TLSStrategy) WithBackoff(b Backoff) *TLSStrategy {
+	ws.backoff = b
+	return ws
+}
+
+// WithRootCAs sets the pool of certificate authorities used to verify the certificate chain
+func (ws *TLSStrategy) WithRootCAs(pool *x509.CertPool) *TLSStrategy {
+	ws.RootCAs = pool
+	return ws
+}
+
+// WithServerName sets the server name sent in the TLS handshake. When a root CA pool is set,
+// the leaf certificate must also be valid for this name.
+func (ws *TLSStrategy) WithServerName(serverName string) *TLSStrategy {
+	ws.ServerName = serverName
+	return ws
+}
+
+// WithSubject requires the leaf certificate to have the given subject common name
+func (ws *TLSStrategy) WithSubject(commonName string) *TLSStrategy {
+	ws.Subject = commonName
+	return ws
+}
+
+// WithSAN requires the leaf certificate to be valid for all the given names,
+// which can be DNS names or IP addresses
+func (ws *TLSStrategy) WithSAN(names ...string) *TLSStrategy {
+	ws.SANs = append(ws.SANs, names...)
+	return ws
+}
+
+// WithCertificateMatcher sets a matcher for the leaf certificate presented by the container
+func (ws *TLSStrategy) WithCertificateMatcher(matcher func(cert *x509.Certificate) bool) *TLSStrategy {
+	ws.CertificateMatcher = matcher
+	return ws
+}
+
+// PeerCertificates returns the certificate chain presented by the container in the
+// last successful handshake, leaf first. It returns nil until the strategy succeeds.
+func (ws *TLSStrategy) PeerCertificates() []*x509.Certificate {
+	ws.mtx.Lock()
+	defer ws.mtx.Unlock()
+
+	return ws.peerCertificates
+}
+
+// CertPool returns a pool with the certificates presented by the container, so that
+// clients can trust them without having to copy them out of the container.
+func (ws *TLSStrategy) CertPool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	for _, cert := range ws.PeerCertificates() {
+		pool.AddCert(cert)
+	}
+	return pool
+}
+
+func (ws *TLSStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (ws *TLSStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	timeout := defaultStartupTimeout()
+	if ws.timeout != nil {
+		timeout = *ws.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	poll := pollBackoff(ctx, ws.backoff, ws.PollInterval)
+
+	ipAddress, err := target.Host(ctx)
+	if err != nil {
+		return err
+	}
+
+	var port nat.Port
+	port, err = target.MappedPort(ctx, ws.Port)
+
+	for port == "" {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-time.After(nextInterval(poll)):
+			if err := checkTarget(ctx, target); err != nil {
+				return err
+			}
+			port, err = target.MappedPort(ctx, ws.Port)
+		}
+	}
+
+	if port.Proto() != "tcp" {
+		return errors.New("cannot use TLS on non-TCP ports")
+	}
+
+	address := net.JoinHostPort(ipAddress, strconv.Itoa(port.Int()))
+
+	var lastErr error
+	for {
+		if err := checkTarget(ctx, target); err != nil {
+			return err
+		}
+
+		recordAttempt(ctx)
+		certs, err := ws.handshake(ctx, address)
+		if err == nil {
+			ws.mtx.Lock()
+			ws.peerCertificates = certs
+			ws.mtx.Unlock()
+			recordDetail(ctx, "tls %s", ws.Port)
+			return nil
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), lastErr)
+		case <-time.After(nextInterval(poll)):
+		}
+	}
+}
+
+// handshake dials the address and performs a TLS handshake, returning the verified
+// certificate chain presented by the server.
+func (ws *TLSStrategy) handshake(ctx context.Context, address string) ([]*x509.Certificate, error) {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: time.Second},
+		Config: &tls.Config{
+			ServerName: ws.ServerName,
+			// verification is done in VerifyConnection, because the container is usually
+			// reached through an address which is not listed in its certificate
+			InsecureSkipVerify: true,
+			VerifyConnection:   ws.verifyConnection,
+		},
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return conn.(*tls.Conn).ConnectionState().PeerCertificates, nil
+}
+
+func (ws *TLSStrategy) verifyConnection(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("no peer certificates presented")
+	}
+
+	leaf := state.PeerCertificates[0]
+
+	if ws.RootCAs != nil {
+		intermediates := x509.NewCertPool()
+		for _, cert := range state.PeerCertificates[1:] {
+			intermediates.AddCert(cert)
+		}
+
+		opts := x509.VerifyOptions{
+			Roots:         ws.RootCAs,
+			Intermediates: intermediates,
+			DNSName:       ws.ServerName,
+		}
+		if _, err := leaf.Verify(opts); err != nil {
+			return err
+		}
+	}
+
+	if ws.Subject != "" && leaf.Subject.CommonName != ws.Subject {
+		return fmt.Errorf("certificate subject %q does not match %q", leaf.Subject.CommonName, ws.Subject)
+	}
+
+	for _, name := range ws.SANs {
+		if err := leaf.VerifyHostname(name); err != nil {
+			return err
+		}
+	}
+
+	if ws.CertificateMatcher != nil && !ws.CertificateMatcher(leaf) {
+		return fmt.Errorf("certificate %q does not match", leaf.Subject)
+	}
+
+	return nil
+}
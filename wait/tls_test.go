@@ -0,0 +1,131 @@
+package wait
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTLSListener starts a TLS server using the certificates in testdata and returns its mapped port
+func newTLSListener(t *testing.T) nat.Port {
+	t.Helper()
+
+	cert, err := tls.LoadX509KeyPair("testdata/tls.pem", "testdata/tls-key.pem")
+	require.NoError(t, err)
+
+	listener, err := tls.Listen("tcp", "localhost:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			_ = conn.Close()
+		}
+	}()
+
+	rawPort := listener.Addr().(*net.TCPAddr).Port
+	port, err := nat.NewPort("tcp", strconv.Itoa(rawPort))
+	require.NoError(t, err)
+
+	return port
+}
+
+func rootCAs(t *testing.T) *x509.CertPool {
+	t.Helper()
+
+	caCert, err := os.ReadFile("testdata/root.pem")
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(caCert))
+
+	return pool
+}
+
+func TestWaitForTLSSucceeds(t *testing.T) {
+	target := newRunningStrategyTarget("localhost", newTLSListener(t))
+
+	wg := ForTLS("443/tcp").
+		WithRootCAs(rootCAs(t)).
+		WithServerName("testcontainer.go.test").
+		WithSubject("testcontainers").
+		WithSAN("localhost", "127.0.0.1").
+		WithStartupTimeout(5 * time.Second)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.NoError(t, err)
+
+	certs := wg.PeerCertificates()
+	require.NotEmpty(t, certs)
+	assert.Equal(t, "testcontainers", certs[0].Subject.CommonName)
+}
+
+func TestWaitForTLSWithoutCASucceeds(t *testing.T) {
+	target := newRunningStrategyTarget("localhost", newTLSListener(t))
+
+	wg := ForTLS("443/tcp").WithStartupTimeout(5 * time.Second)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.NoError(t, err)
+
+	_, err = wg.PeerCertificates()[0].Verify(x509.VerifyOptions{Roots: wg.CertPool()})
+	require.NoError(t, err)
+}
+
+func TestWaitForTLSFailsForUnknownAuthority(t *testing.T) {
+	target := newRunningStrategyTarget("localhost", newTLSListener(t))
+
+	wg := ForTLS("443/tcp").
+		WithRootCAs(x509.NewCertPool()).
+		WithStartupTimeout(500 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	var authErr x509.UnknownAuthorityError
+	assert.True(t, errors.As(err, &authErr))
+	assert.Nil(t, wg.PeerCertificates())
+}
+
+func TestWaitForTLSFailsForMismatchedSAN(t *testing.T) {
+	target := newRunningStrategyTarget("localhost", newTLSListener(t))
+
+	wg := ForTLS("443/tcp").
+		WithSAN("elasticsearch").
+		WithStartupTimeout(500 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.Error(t, err)
+
+	var hostErr x509.HostnameError
+	assert.True(t, errors.As(err, &hostErr))
+}
+
+func TestWaitForTLSFailsDueToExitedContainer(t *testing.T) {
+	target := newRunningStrategyTarget("localhost", "443/tcp")
+	target.StateImpl = func(_ context.Context) (*types.ContainerState, error) {
+		return &types.ContainerState{Status: "exited", ExitCode: 1}, nil
+	}
+
+	wg := ForTLS("443/tcp").WithStartupTimeout(500 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	assert.EqualError(t, err, "container exited with code 1")
+}
@@ -0,0 +1,201 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*UDPStrategy)(nil)
+	_ StrategyTimeout = (*UDPStrategy)(nil)
+)
+
+// maxDatagramSize is the maximum size of a UDP datagram
+const maxDatagramSize = 65535
+
+// UDPStrategy will wait until the container answers a probe datagram sent to a UDP port,
+// such as a DNS query, as UDP services cannot be detected by opening a connection.
+type UDPStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+	// backoff defines the intervals between attempts, overriding the poll interval
+	backoff Backoff
+
+	// additional properties
+	Port    nat.Port
+	Payload []byte
+	// Matcher checks the response datagram. If nil, any response is accepted.
+	Matcher      func(response []byte) bool
+	ReadTimeout  time.Duration
+	PollInterval time.Duration
+}
+
+// NewUDPStrategy constructs a UDP strategy for the given port, with polling interval
+// of 100 milliseconds and startup timeout of 60 seconds by default
+func NewUDPStrategy(port nat.Port) *UDPStrategy {
+	return &UDPStrategy{
+		Port:         port,
+		ReadTimeout:  time.Second,
+		PollInterval: defaultPollInterval(),
+	}
+}
+
+// fluent builders for each property
+// since go has neither covariance nor generics, the return type must be the type of the concrete implementation
+// this is true for all properties, even the "shared" ones like startupTimeout
+
+// ForUDP is the default construction for the fluid interface.
+//
+// For Example:
+//
+//	wait.
+//		ForUDP("53/udp").
+//		Send(dnsQuery).
+//		Expect(isDNSResponse)
+func ForUDP(port nat.Port) *UDPStrategy {
+	return NewUDPStrategy(port)
+}
+
+// Send sets the payload of the probe datagram
+func (ws *UDPStrategy) Send(payload []byte) *UDPStrategy {
+	ws.Payload = payload
+	return ws
+}
+
+// Expect sets the matcher for the response datagram
+func (ws *UDPStrategy) Expect(matcher func(response []byte) bool) *UDPStrategy {
+	ws.Matcher = matcher
+	return ws
+}
+
+// WithReadTimeout can be used to override the default timeout of 1 second to receive a response
+func (ws *UDPStrategy) WithReadTimeout(readTimeout time.Duration) *UDPStrategy {
+	ws.ReadTimeout = readTimeout
+	return ws
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (ws *UDPStrategy) WithStartupTimeout(startupTimeout time.Duration) *UDPStrategy {
+	ws.timeout = &startupTimeout
+	return ws
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ws *UDPStrategy) WithPollInterval(pollInterval time.Duration) *UDPStrategy {
+	ws.PollInterval = pollInterval
+	return ws
+}
+
+// WithBackoff can be used to replace the fixed poll interval by a backoff policy,
+// such as an exponential backoff for slow starting services
+func (ws *UDPStrategy) WithBackoff(b Backoff) *UDPStrategy {
+	ws.backoff = b
+	return ws
+}
+
+func (ws *UDPStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (ws *UDPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	timeout := defaultStartupTimeout()
+	if ws.timeout != nil {
+		timeout = *ws.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	poll := pollBackoff(ctx, ws.backoff, ws.PollInterval)
+
+	ipAddress, err := target.Host(ctx)
+	if err != nil {
+		return err
+	}
+
+	var port nat.Port
+	port, err = target.MappedPort(ctx, ws.Port)
+
+	for port == "" {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-time.After(nextInterval(poll)):
+			if err := checkTarget(ctx, target); err != nil {
+				return err
+			}
+			port, err = target.MappedPort(ctx, ws.Port)
+		}
+	}
+
+	if port.Proto() != "udp" {
+		return errors.New("cannot use UDP strategy on non-UDP ports")
+	}
+
+	address := net.JoinHostPort(ipAddress, strconv.Itoa(port.Int()))
+
+	var lastErr error
+	for {
+		if err := checkTarget(ctx, target); err != nil {
+			return err
+		}
+
+		recordAttempt(ctx)
+		lastErr = ws.probe(ctx, address)
+		if lastErr == nil {
+			recordDetail(ctx, "udp %s", ws.Port)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), lastErr)
+		case <-time.After(nextInterval(poll)):
+		}
+	}
+}
+
+// probe sends the payload to the address and waits for a matching response
+func (ws *UDPStrategy) probe(ctx context.Context, address string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write(ws.Payload); err != nil {
+		return err
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(ws.ReadTimeout)); err != nil {
+		return err
+	}
+
+	// unexpected datagrams are ignored until the read timeout is reached
+	var response []byte
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			// includes the connection refused error caused by an ICMP port unreachable reply
+			if response != nil {
+				return fmt.Errorf("unexpected response %q: %w", response, err)
+			}
+			return err
+		}
+
+		response = buf[:n]
+		if ws.Matcher == nil || ws.Matcher(response) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newUDPServer starts a server which answers each datagram using the handler,
+// returning the port it listens on. No response is sent if the handler returns nil.
+func newUDPServer(t *testing.T, handler func(request []byte) []byte) nat.Port {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, maxDatagramSize)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if response := handler(buf[:n]); response != nil {
+				_, _ = conn.WriteTo(response, addr)
+			}
+		}
+	}()
+
+	rawPort := conn.LocalAddr().(*net.UDPAddr).Port
+	port, err := nat.NewPort("udp", strconv.Itoa(rawPort))
+	require.NoError(t, err)
+
+	return port
+}
+
+func TestWaitForUDPSucceeds(t *testing.T) {
+	var probes int32
+	port := newUDPServer(t, func(request []byte) []byte {
+		if !bytes.Equal(request, []byte("ping")) {
+			return []byte("unknown")
+		}
+		// the first probes are not answered while the server is starting
+		if atomic.AddInt32(&probes, 1) < 3 {
+			return nil
+		}
+		return []byte("pong")
+	})
+
+	wg := ForUDP("8125/udp").
+		Send([]byte("ping")).
+		Expect(ContainsBytes([]byte("pong"))).
+		WithReadTimeout(100 * time.Millisecond).
+		WithPollInterval(10 * time.Millisecond).
+		WithStartupTimeout(5 * time.Second)
+
+	err := wg.WaitUntilReady(context.Background(), newRunningStrategyTarget("127.0.0.1", port))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&probes))
+}
+
+func TestWaitForUDPTimesOutReportingLastResponse(t *testing.T) {
+	port := newUDPServer(t, func(request []byte) []byte {
+		return []byte("SERVFAIL")
+	})
+
+	wg := ForUDP("53/udp").
+		Send([]byte("query")).
+		Expect(HasPrefixBytes([]byte("NOERROR"))).
+		WithReadTimeout(100 * time.Millisecond).
+		WithStartupTimeout(500 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), newRunningStrategyTarget("127.0.0.1", port))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), `unexpected response "SERVFAIL"`)
+}
+
+func TestWaitForUDPFailsForTCPPort(t *testing.T) {
+	wg := ForUDP("53/udp").WithStartupTimeout(500 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), newRunningStrategyTarget("127.0.0.1", "53/tcp"))
+	require.EqualError(t, err, "cannot use UDP strategy on non-UDP ports")
+}
+
+func TestWaitForUDPFailsDueToExitedContainer(t *testing.T) {
+	target := newRunningStrategyTarget("127.0.0.1", "53/udp")
+	target.StateImpl = func(_ context.Context) (*types.ContainerState, error) {
+		return &types.ContainerState{Status: "exited", ExitCode: 1}, nil
+	}
+
+	wg := ForUDP("53/udp").WithStartupTimeout(500 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.EqualError(t, err, "container exited with code 1")
+}
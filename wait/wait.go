@@ -30,6 +30,14 @@ type StrategyTarget interface {
 	Logs(context.Context) (io.ReadCloser, error)
 	Exec(context.Context, []string, ...exec.ProcessOption) (int, io.Reader, error)
 	State(context.Context) (*types.ContainerState, error)
+	CopyFileFromContainer(ctx context.Context, filePath string) (io.ReadCloser, error)
+}
+
+// NetworkNamespaceTarget is implemented by targets which can run commands in a helper
+// sharing the network namespace of the container. It allows strategies to probe the
+// container network from the inside, even if the container image has no shell.
+type NetworkNamespaceTarget interface {
+	ExecInNetworkNamespace(context.Context, []string, ...exec.ProcessOption) (int, io.Reader, error)
 }
 
 func checkTarget(ctx context.Context, target StrategyTarget) error {
@@ -20,6 +20,7 @@ type MockStrategyTarget struct {
 	LogsImpl       func(context.Context) (io.ReadCloser, error)
 	ExecImpl       func(context.Context, []string, ...tcexec.ProcessOption) (int, io.Reader, error)
 	StateImpl      func(context.Context) (*types.ContainerState, error)
+	CopyFileImpl   func(context.Context, string) (io.ReadCloser, error)
 }
 
 func (st MockStrategyTarget) Host(ctx context.Context) (string, error) {
@@ -45,3 +46,22 @@ func (st MockStrategyTarget) Exec(ctx context.Context, cmd []string, options ...
 func (st MockStrategyTarget) State(ctx context.Context) (*types.ContainerState, error) {
 	return st.StateImpl(ctx)
 }
+
+func (st MockStrategyTarget) CopyFileFromContainer(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	return st.CopyFileImpl(ctx, filePath)
+}
+
+// newRunningStrategyTarget returns the target of a running container, whose ports are all mapped to port on host
+func newRunningStrategyTarget(host string, port nat.Port) *MockStrategyTarget {
+	return &MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return host, nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			return port, nil
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+	}
+}